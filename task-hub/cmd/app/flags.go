@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/vagonaizer/workmate/task-hub/internal/config"
+)
+
+// flags — параметры командной строки.
+type flags struct {
+	configPath  string
+	printConfig bool
+	overrides   map[string]string
+}
+
+// overrideFlags — флаги, переопределяющие ключи конфига.
+// -- Приоритет: флаг → APP_* → файл → дефолт; учитываются только явно переданные флаги.
+var overrideFlags = []struct {
+	name, key, usage string
+}{
+	{"port", "server.port", "порт HTTP-сервера (server.port)"},
+	{"db-type", "db.type", "тип хранилища: inmemory, postgres (db.type)"},
+	{"log-level", "logger.level", "уровень логирования: debug, info, warn, error (logger.level)"},
+	{"env", "env", "окружение: dev, prod, test (env)"},
+}
+
+// parseFlags — разбор аргументов; -h выводит справку с порядком приоритета.
+func parseFlags(args []string) (flags, error) {
+	fs := flag.NewFlagSet("task-hub", flag.ContinueOnError)
+	var f flags
+	fs.StringVar(&f.configPath, "config", "", "путь к файлу конфига (по умолчанию config.yml в . или ./configs)")
+	fs.BoolVar(&f.printConfig, "print-config", false, "вывести действующий конфиг (секреты скрыты) и выйти")
+	values := make(map[string]*string, len(overrideFlags))
+	for _, o := range overrideFlags {
+		values[o.name] = fs.String(o.name, "", o.usage)
+	}
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Использование: %s [флаги]\n\n", fs.Name())
+		fs.PrintDefaults()
+		fmt.Fprintln(fs.Output(), "\nПриоритет значений: флаги → переменные окружения APP_* (например, APP_SERVER_PORT) → файл конфига → значения по умолчанию.")
+	}
+	if err := fs.Parse(args); err != nil {
+		return f, err
+	}
+	if fs.NArg() > 0 {
+		return f, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	f.overrides = make(map[string]string)
+	fs.Visit(func(fl *flag.Flag) {
+		for _, o := range overrideFlags {
+			if fl.Name == o.name {
+				f.overrides[o.key] = *values[o.name]
+			}
+		}
+	})
+	return f, nil
+}
+
+// loadConfig — загрузка и проверка конфига по флагам; --print-config печатает конфиг и завершает процесс.
+func loadConfig(args []string) *config.AppConfig {
+	f, err := parseFlags(args)
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	cfg, err := config.Load(config.LoadOptions{File: f.configPath, Overrides: f.overrides})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	validationErr := cfg.Validate()
+	if f.printConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	if validationErr != nil {
+		fmt.Fprintln(os.Stderr, validationErr)
+		os.Exit(1)
+	}
+	if f.printConfig {
+		os.Exit(0)
+	}
+	return cfg
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFlags_OnlyExplicitOverrides(t *testing.T) {
+	f, err := parseFlags([]string{"--config", "/etc/task-hub.yml", "--port", "9090", "--log-level=debug", "--print-config"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/etc/task-hub.yml", f.configPath)
+	assert.True(t, f.printConfig)
+	assert.Equal(t, map[string]string{"server.port": "9090", "logger.level": "debug"}, f.overrides)
+}
+
+func TestParseFlags_Errors(t *testing.T) {
+	_, err := parseFlags([]string{"--unknown"})
+	assert.Error(t, err)
+	_, err = parseFlags([]string{"serve"})
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -11,13 +12,18 @@ import (
 
 	"github.com/vagonaizer/workmate/task-hub/internal/app"
 	"github.com/vagonaizer/workmate/task-hub/internal/config"
-	"github.com/vagonaizer/workmate/task-hub/pkg/logger"
+	"github.com/vagonaizer/workmate/task-hub/internal/version"
 )
 
 func main() {
-	cfg := config.LoadConfig()
-	application := app.NewApp(cfg)
-	application.Logger.Info("Запуск %s v%s на :%s", cfg.AppName, cfg.AppVersion, cfg.Server.Port)
+	cfg := loadConfig(os.Args[1:])
+	application, err := app.NewApp(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	build := version.Get(cfg.AppName, cfg.AppVersion)
+	application.Logger.Info("Запуск %s v%s (%s, %s) на :%s", build.AppName, build.Version, build.Commit, build.BuildTime, cfg.Server.Port)
 	hireMe()
 
 	server := &http.Server{
@@ -32,24 +38,56 @@ func main() {
 		}
 	}()
 
+	// Фоновая очистка корзины
+	application.Purger.Start()
+
+	// Горячая перезагрузка конфига
+	watcher := config.NewWatcher(cfg, application.Logger)
+	application.WatchConfig(watcher)
+	watcher.Start()
+
 	// Graceful shutdown
-	waitForShutdown(server, application.Logger)
+	sig := waitForSignal()
+	application.Logger.Info("Получен сигнал завершения: %v, останавливаем сервер...", sig)
+	if err := shutdown(server, application, cfg.Server); err != nil {
+		application.Logger.Error("Ошибка graceful shutdown: %v", err)
+		os.Exit(1)
+	}
+	application.Logger.Info("Graceful shutdown завершен ( =ω= )")
 	os.Exit(0)
 }
 
-func waitForShutdown(server *http.Server, logger *logger.Logger) {
+func waitForSignal() os.Signal {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	sig := <-quit
-	logger.Info("Получен сигнал завершения: %v, останавливаем сервер...", sig)
+	return <-quit
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// shutdown — остановка в пределах server.shutdowntimeout.
+// -- 1. Снимаем готовность, чтобы оркестратор убрал инстанс из балансировки, и ждем server.shutdowndelay.
+// -- 2. HTTP-сервер перестает принимать соединения и дожидается текущих запросов.
+// -- 3. Фоновые процессы, задачи в работе, экспортеры, репозиторий и логи (App.Shutdown) — тем, что осталось от таймаута.
+func shutdown(server *http.Server, application *app.App, cfg config.ServerConfig) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
+
+	application.Health.SetShuttingDown()
+	if cfg.ShutdownDelay > 0 {
+		application.Logger.Info("Готовность снята, ждем %s перед остановкой HTTP", cfg.ShutdownDelay)
+		select {
+		case <-time.After(cfg.ShutdownDelay):
+		case <-ctx.Done():
+		}
+	}
+
+	var errs []error
 	if err := server.Shutdown(ctx); err != nil {
-		logger.Error("Ошибка graceful shutdown: %v", err)
-	} else {
-		logger.Info("Graceful shutdown завершен ( =ω= )")
+		errs = append(errs, fmt.Errorf("http: %w", err))
+	}
+	if err := application.Shutdown(ctx); err != nil {
+		errs = append(errs, err)
 	}
+	return errors.Join(errs...)
 }
 
 func hireMe() {
@@ -0,0 +1,32 @@
+// openapi-gen — генерация OpenAPI 3 по аннотациям хендлеров, запускается через go generate:
+//
+//	go generate ./task-hub/internal/transport/http
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/vagonaizer/workmate/task-hub/internal/openapi"
+)
+
+func main() {
+	var opts openapi.Options
+	out := flag.String("out", "openapi.json", "файл для записи спецификации")
+	flag.StringVar(&opts.Dir, "dir", ".", "пакет с хендлерами и DTO")
+	flag.StringVar(&opts.Title, "title", openapi.DefaultTitle, "название API")
+	flag.StringVar(&opts.Version, "version", openapi.DefaultVersion, "версия API")
+	flag.StringVar(&opts.SecurePrefix, "secure-prefix", openapi.DefaultSecurePrefix, "префикс маршрутов, требующих аутентификации")
+	flag.Parse()
+
+	data, err := openapi.Generate(opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "openapi-gen:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "openapi-gen:", err)
+		os.Exit(1)
+	}
+}
@@ -1,12 +1,25 @@
 package app
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
 	"github.com/vagonaizer/workmate/task-hub/internal/config"
 	"github.com/vagonaizer/workmate/task-hub/internal/domain/ports"
+	"github.com/vagonaizer/workmate/task-hub/internal/health"
+	"github.com/vagonaizer/workmate/task-hub/internal/idempotency"
+	"github.com/vagonaizer/workmate/task-hub/internal/metrics"
+	"github.com/vagonaizer/workmate/task-hub/internal/ratelimit"
 	inmemory "github.com/vagonaizer/workmate/task-hub/internal/repository/in-memory"
 	service "github.com/vagonaizer/workmate/task-hub/internal/services/task-service"
+	"github.com/vagonaizer/workmate/task-hub/internal/tracing"
 	"github.com/vagonaizer/workmate/task-hub/internal/transport/http"
+	"github.com/vagonaizer/workmate/task-hub/internal/version"
 	"github.com/vagonaizer/workmate/task-hub/pkg/logger"
 )
 
@@ -14,13 +27,31 @@ import (
 type App struct {
 	Engine      *gin.Engine
 	TaskService ports.TaskService
+	Purger      *service.Purger
+	Metrics     *metrics.Metrics
+	Health      *health.Checker
+	Tracing     *tracing.Provider
 	Logger      *logger.Logger
+
+	service     *service.TaskService // без декораторов: нужен для настроек, меняемых на лету
+	idempotency *idempotency.Store
+	rateLimiter *ratelimit.Limiter
+	timeout     *http.RequestTimeout
+	repo        ports.TaskRepository // без декораторов: закрывается при остановке
 }
 
 // NewApp — собирает все зависимости и возвращает готовое приложение.
-func NewApp(cfg *config.AppConfig) *App {
+// -- Ошибка — конфиг, с которым приложение не может работать (хранилище, ключи JWT); main завершает процесс.
+func NewApp(cfg *config.AppConfig) (*App, error) {
 	// 1. Логгер
-	logg := logger.NewLogger()
+	logg, err := logger.New(logger.Options{
+		Level:  cfg.Logger.Level,
+		Format: logger.Format(cfg.Logger.Format),
+		Color:  !cfg.IsProduction(),
+	})
+	if err != nil {
+		logg.Warn("Некорректный конфиг логгера: %v", err)
+	}
 
 	// 2. Репозиторий (можно расширить switch для других хранилищ)
 	var taskRepo ports.TaskRepository
@@ -29,24 +60,204 @@ func NewApp(cfg *config.AppConfig) *App {
 		taskRepo = inmemory.NewInMemoryTaskRepository()
 		logg.Info("Используется in-memory репозиторий")
 	case config.DBPostgres:
-		logg.Error("УПС, постгрес пока не поддерживается")
+		return nil, errors.New("repository: postgres is not implemented yet")
 	default:
-		logg.Error("Неизвестный тип репозитория: %v", cfg.DB.Type)
-		panic("unknown repository type")
+		return nil, fmt.Errorf("repository: unknown type %v", cfg.DB.Type)
 	}
 
-	// 3. Сервис
-	taskService := service.NewTaskService(taskRepo)
+	// 3. Трассировка: без нее глобальный провайдер no-op, и спаны никуда не пишутся.
+	var tracer *tracing.Provider
+	if cfg.Tracing.Enabled {
+		tracer, err = tracing.Setup(context.Background(), tracing.Options{
+			ServiceName:    cfg.AppName,
+			ServiceVersion: cfg.AppVersion,
+			Exporter:       tracing.Exporter(cfg.Tracing.Exporter),
+			File:           cfg.Tracing.File,
+			Endpoint:       cfg.Tracing.Endpoint,
+			Insecure:       cfg.Tracing.Insecure,
+			SampleRatio:    cfg.Tracing.SampleRatio,
+		})
+		if err != nil {
+			logg.Error("Трассировка отключена: %v", err)
+		} else {
+			logg.Info("Трассировка включена, экспортер: %s", cfg.Tracing.Exporter)
+		}
+	}
+
+	// 4. Метрики и сервис
+	appMetrics := metrics.New()
+	// Без аутентификации вызывающего нет, поэтому политика пропускает анонимные вызовы.
+	// Сервис и репозиторий оборачиваются спанами; проверка готовности ниже пингует исходный репозиторий.
+	coreService := service.NewTaskService(tracing.WrapRepository(taskRepo), auth.NewPolicy(!cfg.Auth.Enabled), service.Quotas{
+		Default:      cfg.Tenancy.DefaultQuota,
+		PerWorkspace: cfg.Tenancy.Quotas,
+	}, logg, appMetrics)
+	taskService := tracing.WrapService(coreService)
+	purger := service.NewPurger(taskService, cfg.Task.TrashRetention, cfg.Task.PurgeInterval, logg)
 
-	// 4. Handler
+	// 5. API-ключи из конфига; скоупы и хеши уже проверены Validate, ошибка здесь — конфиг в обход проверки
+	keys := auth.NewAPIKeyStore()
+	for _, k := range cfg.Auth.APIKeys {
+		scopes := make([]auth.Scope, 0, len(k.Scopes))
+		for _, s := range k.Scopes {
+			scope, err := auth.ParseScope(s)
+			if err != nil {
+				return nil, fmt.Errorf("auth.apikeys %s: %w", k.Name, err)
+			}
+			scopes = append(scopes, scope)
+		}
+		if err := keys.Add(k.Name, k.Hash, scopes, k.Workspace); err != nil {
+			return nil, fmt.Errorf("auth.apikeys %s: %w", k.Name, err)
+		}
+	}
+	if cfg.Auth.Enabled {
+		logg.Info("Аутентификация по API-ключам включена, ключей: %d", len(keys.List()))
+	}
+
+	// 6. Проверка JWT (ключи только из локальных файлов/секрета)
+	var jwtVerifier *auth.JWTVerifier
+	if cfg.Auth.JWT.Enabled {
+		verifier, err := auth.NewJWTVerifier(auth.JWTOptions{
+			Algorithm:      cfg.Auth.JWT.Algorithm,
+			Secret:         cfg.Auth.JWT.Secret,
+			PublicKeyFile:  cfg.Auth.JWT.PublicKeyFile,
+			JWKSFile:       cfg.Auth.JWT.JWKSFile,
+			Issuer:         cfg.Auth.JWT.Issuer,
+			Audience:       cfg.Auth.JWT.Audience,
+			Leeway:         cfg.Auth.JWT.Leeway,
+			RolesClaim:     cfg.Auth.JWT.RolesClaim,
+			WorkspaceClaim: cfg.Auth.JWT.WorkspaceClaim,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("auth.jwt: %w", err)
+		}
+		jwtVerifier = verifier
+		logg.Info("Аутентификация по JWT (%s) включена", cfg.Auth.JWT.Algorithm)
+	}
+
+	// 7. Проверки готовности
+	checker := health.New()
+	if pinger, ok := taskRepo.(ports.HealthChecker); ok {
+		checker.Add("repository", pinger.Ping)
+	}
+	checker.Add("purger", func(context.Context) error {
+		if !purger.Running() {
+			return errors.New("purger is not running")
+		}
+		return nil
+	})
+
+	// 8. Handler
 	handler := http.NewHandler(taskService, logg)
+	authHandler := http.NewAuthHandler(keys, jwtVerifier, cfg.Auth.Enabled, logg)
+	healthHandler := http.NewHealthHandler(checker, version.Get(cfg.AppName, cfg.AppVersion))
 
-	// 5. Gin + роуты
-	engine := http.SetupRouter(handler)
+	// 9. Gin + роуты; ответы по Idempotency-Key и счетчики лимита запросов хранятся в памяти процесса
+	idempotencyStore := idempotency.NewStore(cfg.API.Idempotency.TTL)
+	rateLimiter := ratelimit.New(cfg.API.RateLimit.RPS, cfg.API.RateLimit.Burst)
+	timeout := http.NewRequestTimeout(cfg.Task.Timeout)
+	engine := http.SetupRouter(handler, authHandler, healthHandler, appMetrics, http.APIOptions{
+		Legacy:             cfg.API.Legacy.Enabled,
+		Deprecation:        cfg.API.Legacy.Deprecation,
+		Sunset:             cfg.API.Legacy.Sunset,
+		Idempotency:        idempotencyStore,
+		IdempotencyMaxBody: cfg.API.Idempotency.MaxBody,
+		RateLimiter:        rateLimiter,
+		Timeout:            timeout,
+	})
 
 	return &App{
 		Engine:      engine,
 		TaskService: taskService,
+		Purger:      purger,
+		Metrics:     appMetrics,
+		Health:      checker,
+		Tracing:     tracer,
+		service:     coreService,
+		idempotency: idempotencyStore,
+		rateLimiter: rateLimiter,
+		timeout:     timeout,
+		repo:        taskRepo,
 		Logger:      logg,
+	}, nil
+}
+
+// WatchConfig — подписка подсистем на изменения конфига, которые можно применить без перезапуска:
+// уровень логирования, дедлайн запросов к задачам, лимит частоты запросов, расписание очистки корзины,
+// квоты рабочих пространств и TTL ключей идемпотентности.
+func (a *App) WatchConfig(w *config.Watcher) {
+	config.Watch(w, "logger.level",
+		func(c *config.AppConfig) string { return c.Logger.Level },
+		func(_, level string) error { return a.Logger.SetLevel(level) },
+	)
+	config.Watch(w, "task.timeout",
+		func(c *config.AppConfig) time.Duration { return c.Task.Timeout },
+		func(_, timeout time.Duration) error {
+			a.timeout.Set(timeout)
+			return nil
+		},
+	)
+	config.Watch(w, "api.ratelimit",
+		func(c *config.AppConfig) config.RateLimitConfig { return c.API.RateLimit },
+		func(_, next config.RateLimitConfig) error {
+			a.rateLimiter.SetLimit(next.RPS, next.Burst)
+			return nil
+		},
+	)
+	config.Watch(w, "task.purge",
+		func(c *config.AppConfig) [2]time.Duration {
+			return [2]time.Duration{c.Task.TrashRetention, c.Task.PurgeInterval}
+		},
+		func(_, next [2]time.Duration) error {
+			a.Purger.SetSchedule(next[0], next[1])
+			return nil
+		},
+	)
+	config.Watch(w, "tenancy",
+		func(c *config.AppConfig) config.TenancyConfig { return c.Tenancy },
+		func(_, next config.TenancyConfig) error {
+			a.service.SetQuotas(service.Quotas{Default: next.DefaultQuota, PerWorkspace: next.Quotas})
+			return nil
+		},
+	)
+	config.Watch(w, "api.idempotency.ttl",
+		func(c *config.AppConfig) time.Duration { return c.API.Idempotency.TTL },
+		func(_, ttl time.Duration) error {
+			a.idempotency.SetTTL(ttl)
+			return nil
+		},
+	)
+}
+
+// Shutdown — остановка всего, кроме HTTP-сервера (его останавливает main раньше, чтобы дослать ответы).
+// -- 1. Готовность снимается (если еще не снята).
+// -- 2. Фоновые процессы: новые проходы не запускаются, текущим дается закончить до истечения ctx.
+// -- 3. Задачи в работе возвращаются в "pending" с закрытым интервалом работы — до закрытия репозитория.
+// -- 4. Досылаются трассы, закрывается репозиторий, сбрасываются логи.
+// -- Шаги выполняются все, даже если предыдущий завершился ошибкой; ошибки объединяются.
+func (a *App) Shutdown(ctx context.Context) error {
+	a.Health.SetShuttingDown()
+
+	var errs []error
+	if err := a.Purger.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("purger: %w", err))
+	}
+	checkpointed, err := a.TaskService.CheckpointRunning(auth.WithPrincipal(ctx, auth.System))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("checkpoint: %w", err))
+	} else if checkpointed > 0 {
+		a.Logger.Info("Задач в работе возвращено в pending: %d", checkpointed)
+	}
+	if err := a.Tracing.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("tracing: %w", err))
+	}
+	if closer, ok := a.repo.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("repository: %w", err))
+		}
+	}
+	if err := a.Logger.Sync(); err != nil {
+		errs = append(errs, fmt.Errorf("logger: %w", err))
 	}
+	return errors.Join(errs...)
 }
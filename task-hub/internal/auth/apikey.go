@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	ErrKeyExists     = errors.New("api key with this name already exists")
+	ErrKeyNotFound   = errors.New("api key not found")
+)
+
+// APIKey — API-ключ. Сам ключ не хранится, только его sha256-хеш.
+type APIKey struct {
+	Name      string
+	Hash      string
+	Scopes    []Scope
+	Workspace string // пусто — ключ не привязан к рабочему пространству
+}
+
+// HashKey — sha256-хеш ключа в hex, в таком виде ключи лежат в конфиге и в хранилище.
+// -- Ключи генерируются случайно и длинные, поэтому медленный KDF здесь не нужен.
+func HashKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyStore — потокобезопасное in-memory хранилище ключей.
+type APIKeyStore struct {
+	mu   sync.RWMutex
+	keys map[string]*APIKey // name -> key
+}
+
+// Конструктор.
+func NewAPIKeyStore() *APIKeyStore {
+	return &APIKeyStore{keys: make(map[string]*APIKey)}
+}
+
+// Add — добавляет ключ по уже посчитанному хешу (например, из конфига).
+// -- Хеш приводится к нижнему регистру, как у HashKey, иначе ключ с хешем в верхнем регистре не пройдет Authenticate.
+func (s *APIKeyStore) Add(name, hash string, scopes []Scope, workspace string) error {
+	hash = strings.ToLower(hash)
+	if name == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidAPIKey)
+	}
+	if _, err := hex.DecodeString(hash); err != nil || len(hash) != sha256.Size*2 {
+		return fmt.Errorf("%w: hash must be sha256 hex", ErrInvalidAPIKey)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[name]; ok {
+		return ErrKeyExists
+	}
+	s.keys[name] = &APIKey{Name: name, Hash: hash, Scopes: scopes, Workspace: workspace}
+	return nil
+}
+
+// Generate — создает новый случайный ключ и возвращает его в открытом виде.
+// -- Открытый ключ отдается только один раз, дальше хранится лишь хеш.
+func (s *APIKeyStore) Generate(name string, scopes []Scope, workspace string) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	raw := "th_" + hex.EncodeToString(buf)
+	if err := s.Add(name, HashKey(raw), scopes, workspace); err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+// Revoke — удаляет ключ по имени.
+func (s *APIKeyStore) Revoke(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[name]; !ok {
+		return ErrKeyNotFound
+	}
+	delete(s.keys, name)
+	return nil
+}
+
+// List — список ключей, отсортированный по имени.
+func (s *APIKeyStore) List() []APIKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := make([]APIKey, 0, len(s.keys))
+	for _, k := range s.keys {
+		result = append(result, *k)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Authenticate — проверяет открытый ключ и возвращает вызывающего.
+// -- Хеши сравниваются за постоянное время.
+func (s *APIKeyStore) Authenticate(raw string) (*Principal, error) {
+	if raw == "" {
+		return nil, ErrInvalidAPIKey
+	}
+	hash := []byte(HashKey(raw))
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, k := range s.keys {
+		if subtle.ConstantTimeCompare(hash, []byte(k.Hash)) == 1 {
+			return &Principal{Subject: k.Name, Scopes: k.Scopes, Workspace: k.Workspace}, nil
+		}
+	}
+	return nil, ErrInvalidAPIKey
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyStore_GenerateAndAuthenticate(t *testing.T) {
+	store := NewAPIKeyStore()
+	raw, err := store.Generate("ci", []Scope{ScopeTasksRead}, "team-a")
+	assert.NoError(t, err)
+
+	p, err := store.Authenticate(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, "ci", p.Subject)
+	assert.Equal(t, "team-a", p.Workspace)
+	assert.True(t, p.HasScope(ScopeTasksRead))
+	assert.False(t, p.HasScope(ScopeTasksWrite))
+
+	for _, k := range store.List() {
+		assert.NotEqual(t, raw, k.Hash)
+	}
+
+	_, err = store.Authenticate("th_wrong")
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+}
+
+func TestAPIKeyStore_AddFromConfigHash(t *testing.T) {
+	store := NewAPIKeyStore()
+	assert.NoError(t, store.Add("ops", HashKey("secret"), []Scope{ScopeAdmin}, ""))
+	assert.ErrorIs(t, store.Add("ops", HashKey("other"), nil, ""), ErrKeyExists)
+	assert.Error(t, store.Add("bad", "not-a-hash", nil, ""))
+
+	p, err := store.Authenticate("secret")
+	assert.NoError(t, err)
+	assert.True(t, p.HasScope(ScopeTasksWrite), "admin implies every scope")
+}
+
+func TestAPIKeyStore_AddUppercaseHash(t *testing.T) {
+	store := NewAPIKeyStore()
+	if !assert.NoError(t, store.Add("ops", strings.ToUpper(HashKey("secret")), []Scope{ScopeTasksRead}, "")) {
+		return
+	}
+
+	p, err := store.Authenticate("secret")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "ops", p.Subject)
+	assert.Equal(t, HashKey("secret"), store.List()[0].Hash)
+}
+
+func TestAPIKeyStore_Revoke(t *testing.T) {
+	store := NewAPIKeyStore()
+	raw, _ := store.Generate("tmp", []Scope{ScopeTasksRead}, "")
+	assert.NoError(t, store.Revoke("tmp"))
+	assert.ErrorIs(t, store.Revoke("tmp"), ErrKeyNotFound)
+
+	_, err := store.Authenticate(raw)
+	assert.Error(t, err)
+}
@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// JWTOptions — параметры проверки JWT.
+// -- Ключи берутся только локально (секрет, PEM или JWKS-файл), по сети ничего не загружается.
+type JWTOptions struct {
+	Algorithm      string        // HS256 или RS256
+	Secret         string        // секрет для HS256
+	PublicKeyFile  string        // PEM с публичным ключом для RS256
+	JWKSFile       string        // JWKS-файл с публичными ключами для RS256 (выбор по kid)
+	Issuer         string        // ожидаемый iss, пусто — не проверяется
+	Audience       string        // ожидаемый aud, пусто — не проверяется
+	Leeway         time.Duration // допустимый рассинхрон часов для exp/nbf
+	RolesClaim     string        // claim с ролями, по умолчанию "roles"
+	WorkspaceClaim string        // claim с рабочим пространством, по умолчанию "workspace"
+}
+
+// JWTVerifier — проверка bearer-токенов.
+type JWTVerifier struct {
+	opts    JWTOptions
+	secret  []byte
+	rsaKeys map[string]*rsa.PublicKey // kid -> key; для PEM — единственный ключ с пустым kid
+	parser  *jwt.Parser
+}
+
+// NewJWTVerifier — конструктор, загружает ключи из конфига.
+func NewJWTVerifier(opts JWTOptions) (*JWTVerifier, error) {
+	if opts.RolesClaim == "" {
+		opts.RolesClaim = "roles"
+	}
+	if opts.WorkspaceClaim == "" {
+		opts.WorkspaceClaim = "workspace"
+	}
+	v := &JWTVerifier{opts: opts, rsaKeys: make(map[string]*rsa.PublicKey)}
+
+	switch opts.Algorithm {
+	case "HS256":
+		if opts.Secret == "" {
+			return nil, errors.New("jwt: secret is required for HS256")
+		}
+		v.secret = []byte(opts.Secret)
+	case "RS256":
+		if err := v.loadRSAKeys(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("jwt: unsupported algorithm %q", opts.Algorithm)
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{opts.Algorithm}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(opts.Leeway),
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+	v.parser = jwt.NewParser(parserOpts...)
+	return v, nil
+}
+
+// Verify — проверяет подпись, exp/nbf/iss/aud и возвращает вызывающего.
+// -- Скоупы берутся из claim "scope" (через пробел), иначе выводятся из ролей.
+func (v *JWTVerifier) Verify(raw string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(raw, claims, v.keyFunc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, fmt.Errorf("%w: sub is required", ErrInvalidToken)
+	}
+
+	roles := stringsClaim(claims[v.opts.RolesClaim])
+	var scopes []Scope
+	for _, s := range stringsClaim(claims["scope"]) {
+		if scope, err := ParseScope(s); err == nil {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		scopes = ScopesForRoles(roles)
+	}
+	workspace, _ := claims[v.opts.WorkspaceClaim].(string)
+	return &Principal{Subject: subject, Roles: roles, Scopes: scopes, Workspace: workspace}, nil
+}
+
+func (v *JWTVerifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	if v.secret != nil {
+		return v.secret, nil
+	}
+	kid, _ := token.Header["kid"].(string)
+	if key, ok := v.rsaKeys[kid]; ok {
+		return key, nil
+	}
+	// Токен без kid допустим, если ключ ровно один.
+	if kid == "" && len(v.rsaKeys) == 1 {
+		for _, key := range v.rsaKeys {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown key id %q", kid)
+}
+
+func (v *JWTVerifier) loadRSAKeys() error {
+	switch {
+	case v.opts.JWKSFile != "":
+		data, err := os.ReadFile(v.opts.JWKSFile)
+		if err != nil {
+			return fmt.Errorf("jwt: read jwks: %w", err)
+		}
+		keys, err := parseJWKS(data)
+		if err != nil {
+			return err
+		}
+		v.rsaKeys = keys
+	case v.opts.PublicKeyFile != "":
+		data, err := os.ReadFile(v.opts.PublicKeyFile)
+		if err != nil {
+			return fmt.Errorf("jwt: read public key: %w", err)
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return fmt.Errorf("jwt: parse public key: %w", err)
+		}
+		v.rsaKeys[""] = key
+	default:
+		return errors.New("jwt: publickeyfile or jwksfile is required for RS256")
+	}
+	return nil
+}
+
+// parseJWKS — разбор RSA-ключей из JWKS; ключи других типов пропускаются.
+func parseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("jwt: parse jwks: %w", err)
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: jwks key %q: bad modulus: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: jwks key %q: bad exponent: %w", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwt: jwks has no RSA keys")
+	}
+	return keys, nil
+}
+
+// stringsClaim — claim в виде списка строк: JSON-массив или строка через пробел.
+func stringsClaim(v interface{}) []string {
+	switch val := v.(type) {
+	case string:
+		return strings.Fields(val)
+	case []interface{}:
+		result := make([]string, 0, len(val))
+		for _, item := range val {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func signHS256(t *testing.T, secret string, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	assert.NoError(t, err)
+	return token
+}
+
+func TestJWTVerifier_HS256(t *testing.T) {
+	v, err := NewJWTVerifier(JWTOptions{
+		Algorithm: "HS256",
+		Secret:    "s3cret",
+		Issuer:    "sso",
+		Audience:  "task-hub",
+	})
+	assert.NoError(t, err)
+
+	valid := jwt.MapClaims{
+		"sub":   "alice",
+		"iss":   "sso",
+		"aud":   "task-hub",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"member"},
+	}
+	p, err := v.Verify(signHS256(t, "s3cret", valid))
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", p.Subject)
+	assert.Equal(t, []string{"member"}, p.Roles)
+	assert.True(t, p.HasScope(ScopeTasksWrite))
+	assert.False(t, p.HasScope(ScopeAdmin))
+
+	cases := map[string]func(c jwt.MapClaims){
+		"expired":    func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"no exp":     func(c jwt.MapClaims) { delete(c, "exp") },
+		"not yet":    func(c jwt.MapClaims) { c["nbf"] = time.Now().Add(time.Hour).Unix() },
+		"wrong iss":  func(c jwt.MapClaims) { c["iss"] = "other" },
+		"wrong aud":  func(c jwt.MapClaims) { c["aud"] = "other" },
+		"no subject": func(c jwt.MapClaims) { delete(c, "sub") },
+	}
+	for name, mutate := range cases {
+		claims := jwt.MapClaims{}
+		for k, val := range valid {
+			claims[k] = val
+		}
+		mutate(claims)
+		_, err := v.Verify(signHS256(t, "s3cret", claims))
+		assert.ErrorIs(t, err, ErrInvalidToken, name)
+	}
+
+	_, err = v.Verify(signHS256(t, "other-secret", valid))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestJWTVerifier_RS256_JWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	jwks, _ := json.Marshal(map[string]any{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": "k1",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}})
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(path, jwks, 0o600))
+
+	v, err := NewJWTVerifier(JWTOptions{Algorithm: "RS256", JWKSFile: path})
+	assert.NoError(t, err)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub":   "bob",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": "tasks:read",
+	})
+	token.Header["kid"] = "k1"
+	raw, err := token.SignedString(key)
+	assert.NoError(t, err)
+
+	p, err := v.Verify(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, "bob", p.Subject)
+	assert.Equal(t, []Scope{ScopeTasksRead}, p.Scopes)
+
+	// HS256-токен не должен приниматься RS256-верификатором.
+	_, err = v.Verify(signHS256(t, "x", jwt.MapClaims{"sub": "eve", "exp": time.Now().Add(time.Hour).Unix()}))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
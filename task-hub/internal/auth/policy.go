@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+)
+
+// Action — операция над задачами, которую проверяет политика.
+type Action string
+
+const (
+	ActionRead       Action = "read"
+	ActionCreate     Action = "create"
+	ActionUpdate     Action = "update"
+	ActionDelete     Action = "delete"
+	ActionRestore    Action = "restore"
+	ActionPurge      Action = "purge"
+	ActionCheckpoint Action = "checkpoint"
+	ActionImport     Action = "import"
+)
+
+// System — внутренний вызывающий для фоновых процессов (очистка корзины и т.п.).
+var System = &Principal{Subject: "system", Roles: []string{RoleAdmin}, Scopes: []Scope{ScopeAdmin}}
+
+// Role — итоговая роль вызывающего.
+// -- Берется старшая из ролей токена; для API-ключей (без ролей) выводится из скоупов.
+func (p *Principal) Role() string {
+	roles := p.Roles
+	if len(roles) == 0 {
+		for _, s := range p.Scopes {
+			switch s {
+			case ScopeAdmin:
+				roles = append(roles, RoleAdmin)
+			case ScopeTasksWrite:
+				roles = append(roles, RoleMember)
+			case ScopeTasksRead:
+				roles = append(roles, RoleViewer)
+			}
+		}
+	}
+	role := ""
+	for _, r := range roles {
+		switch {
+		case r == RoleAdmin:
+			return RoleAdmin
+		case r == RoleMember:
+			role = RoleMember
+		case r == RoleViewer && role == "":
+			role = RoleViewer
+		}
+	}
+	return role
+}
+
+// Policy — правила доступа к задачам на сервисном уровне.
+// -- 1. admin может все.
+// -- 2. member читает и создает задачи, а изменяет/удаляет только те, что создал сам или на которые назначен.
+// -- 3. viewer только читает.
+// -- 4. Очистка корзины, возврат задач в ожидание при остановке и импорт (он сохраняет авторство из выгрузки) — только admin.
+// Проверка живет в сервисе, поэтому ее соблюдает любой транспорт, а не только HTTP.
+type Policy struct {
+	allowAnonymous bool
+}
+
+// NewPolicy — конструктор; allowAnonymous разрешает вызовы без вызывающего в контексте
+// (используется, когда аутентификация выключена).
+func NewPolicy(allowAnonymous bool) *Policy {
+	return &Policy{allowAnonymous: allowAnonymous}
+}
+
+// Authorize — проверяет, может ли вызывающий из контекста выполнить действие над задачей.
+// -- task может быть nil для действий, не привязанных к конкретной задаче (создание, список).
+func (p *Policy) Authorize(ctx context.Context, action Action, task *models.Task) error {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok {
+		if p.allowAnonymous {
+			return nil
+		}
+		return apperror.ErrServiceForbidden.WithField("principal", "authentication required")
+	}
+
+	switch principal.Role() {
+	case RoleAdmin:
+		return nil
+	case RoleMember:
+		switch action {
+		case ActionRead, ActionCreate:
+			return nil
+		case ActionUpdate, ActionDelete, ActionRestore:
+			if task != nil && (task.CreatedBy() == principal.Subject || task.Assignee() == principal.Subject) {
+				return nil
+			}
+			return forbidden(action, "members may only modify tasks they created or are assigned to")
+		default:
+			return forbidden(action, "admin role required")
+		}
+	case RoleViewer:
+		if action == ActionRead {
+			return nil
+		}
+		return forbidden(action, "viewers have read-only access")
+	default:
+		return forbidden(action, "no role granted")
+	}
+}
+
+func forbidden(action Action, reason string) error {
+	return apperror.ErrServiceForbidden.WithField(string(action), reason)
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+)
+
+func as(subject string, roles ...string) context.Context {
+	return WithPrincipal(context.Background(), &Principal{Subject: subject, Roles: roles})
+}
+
+func TestPolicy_Roles(t *testing.T) {
+	policy := NewPolicy(false)
+	task, _ := models.NewTask("Test", "desc", models.TaskPriorityLow)
+	task.SetCreatedBy("alice")
+
+	// viewer — только чтение
+	assert.NoError(t, policy.Authorize(as("vic", RoleViewer), ActionRead, task))
+	assert.True(t, errors.Is(policy.Authorize(as("vic", RoleViewer), ActionCreate, nil), apperror.ErrServiceForbidden))
+
+	// member — свои и назначенные задачи
+	assert.NoError(t, policy.Authorize(as("alice", RoleMember), ActionUpdate, task))
+	assert.Error(t, policy.Authorize(as("bob", RoleMember), ActionUpdate, task))
+	_ = task.SetAssignee("bob")
+	assert.NoError(t, policy.Authorize(as("bob", RoleMember), ActionDelete, task))
+	assert.Error(t, policy.Authorize(as("alice", RoleMember), ActionPurge, nil))
+	assert.Error(t, policy.Authorize(as("alice", RoleMember), ActionImport, nil))
+
+	// admin — все
+	assert.NoError(t, policy.Authorize(as("root", RoleViewer, RoleAdmin), ActionImport, nil))
+	assert.NoError(t, policy.Authorize(WithPrincipal(context.Background(), System), ActionPurge, nil))
+}
+
+func TestPolicy_Anonymous(t *testing.T) {
+	assert.NoError(t, NewPolicy(true).Authorize(context.Background(), ActionPurge, nil))
+	assert.True(t, errors.Is(NewPolicy(false).Authorize(context.Background(), ActionRead, nil), apperror.ErrServiceForbidden))
+}
+
+func TestPrincipal_RoleFromScopes(t *testing.T) {
+	assert.Equal(t, RoleViewer, (&Principal{Scopes: []Scope{ScopeTasksRead}}).Role())
+	assert.Equal(t, RoleMember, (&Principal{Scopes: []Scope{ScopeTasksRead, ScopeTasksWrite}}).Role())
+	assert.Equal(t, RoleAdmin, (&Principal{Scopes: []Scope{ScopeAdmin}}).Role())
+	assert.Equal(t, "", (&Principal{}).Role())
+}
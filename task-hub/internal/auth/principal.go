@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+)
+
+// Scope — право доступа, выдаваемое ключу или токену.
+type Scope string
+
+const (
+	ScopeTasksRead  Scope = "tasks:read"
+	ScopeTasksWrite Scope = "tasks:write"
+	ScopeAdmin      Scope = "admin"
+)
+
+// ParseScope — парсинг скоупа из строки.
+func ParseScope(s string) (Scope, error) {
+	switch Scope(s) {
+	case ScopeTasksRead, ScopeTasksWrite, ScopeAdmin:
+		return Scope(s), nil
+	default:
+		return "", fmt.Errorf("unknown scope %q", s)
+	}
+}
+
+// Роли вызывающего (из токена).
+const (
+	RoleViewer = "viewer"
+	RoleMember = "member"
+	RoleAdmin  = "admin"
+)
+
+// ScopesForRoles — права по ролям, если токен не несет скоупы явно.
+// -- viewer читает, member читает и пишет, admin может все.
+func ScopesForRoles(roles []string) []Scope {
+	var scopes []Scope
+	for _, r := range roles {
+		switch r {
+		case RoleViewer:
+			scopes = append(scopes, ScopeTasksRead)
+		case RoleMember:
+			scopes = append(scopes, ScopeTasksRead, ScopeTasksWrite)
+		case RoleAdmin:
+			scopes = append(scopes, ScopeAdmin)
+		}
+	}
+	return scopes
+}
+
+// Principal — аутентифицированный вызывающий: кто он и что ему разрешено.
+type Principal struct {
+	Subject   string   // имя ключа или subject токена
+	Roles     []string // роли из токена (для API-ключей пусто)
+	Scopes    []Scope  // выданные права
+	Workspace string   // рабочее пространство, к которому привязан ключ/токен; пусто — любое
+}
+
+// HasScope — проверка права; admin подразумевает все остальные.
+func (p *Principal) HasScope(scope Scope) bool {
+	for _, s := range p.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// WithPrincipal — кладет вызывающего в контекст.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFrom — достает вызывающего из контекста.
+func PrincipalFrom(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
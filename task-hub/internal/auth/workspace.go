@@ -0,0 +1,21 @@
+package auth
+
+import "context"
+
+// DefaultWorkspace — рабочее пространство для запросов, не указавших свое.
+const DefaultWorkspace = "default"
+
+type workspaceKey struct{}
+
+// WithWorkspace — кладет рабочее пространство (тенант) в контекст.
+func WithWorkspace(ctx context.Context, workspace string) context.Context {
+	return context.WithValue(ctx, workspaceKey{}, workspace)
+}
+
+// WorkspaceFrom — рабочее пространство из контекста, DefaultWorkspace если не задано.
+func WorkspaceFrom(ctx context.Context) string {
+	if ws, ok := ctx.Value(workspaceKey{}).(string); ok && ws != "" {
+		return ws
+	}
+	return DefaultWorkspace
+}
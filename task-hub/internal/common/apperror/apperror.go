@@ -3,9 +3,10 @@ package apperror
 import "fmt"
 
 type AppError struct {
-	Code    string // код ошибки
-	Message string // сообщение об ошибке
-	Err     error  // ошибка
+	Code    string            // код ошибки
+	Message string            // сообщение об ошибке
+	Err     error             // ошибка
+	Details map[string]string // подробности по полям: поле -> что с ним не так
 }
 
 // Конструкторы на два кейса:
@@ -19,9 +20,40 @@ func Wrap(code, message string, err error) *AppError {
 	return &AppError{Code: code, Message: message, Err: err}
 }
 
+// Wrap — копия ошибки с вложенной причиной.
+// -- Предопределенные ошибки (ErrServiceValidation и т.д.) общие, поэтому не мутируем их, а копируем.
+func (e *AppError) Wrap(err error) *AppError {
+	return &AppError{Code: e.Code, Message: e.Message, Err: err, Details: e.Details}
+}
+
+// WithField — копия ошибки с подробностью по конкретному полю.
+func (e *AppError) WithField(field, message string) *AppError {
+	details := make(map[string]string, len(e.Details)+1)
+	for k, v := range e.Details {
+		details[k] = v
+	}
+	details[field] = message
+	return &AppError{Code: e.Code, Message: e.Message, Err: e.Err, Details: details}
+}
+
 func (e *AppError) Error() string {
 	if e.Err != nil {
 		return fmt.Sprintf("%s: %s (%v)", e.Code, e.Message, e.Err)
 	}
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
+
+// Unwrap — доступ к причине для errors.Is/errors.As.
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// Is — ошибки с одинаковым кодом считаются одной и той же ошибкой,
+// так errors.Is(err, ErrServiceValidation) работает и для копий с причиной/подробностями.
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code
+}
@@ -0,0 +1,33 @@
+package apperror
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppError_IsByCode(t *testing.T) {
+	cause := errors.New("deadline must be in the future")
+	err := ErrServiceValidation.Wrap(cause).WithField("deadline", cause.Error())
+
+	assert.True(t, errors.Is(err, ErrServiceValidation))
+	assert.True(t, errors.Is(err, cause))
+	assert.False(t, errors.Is(err, ErrServiceConflict))
+	assert.Equal(t, map[string]string{"deadline": cause.Error()}, err.Details)
+}
+
+func TestAppError_As(t *testing.T) {
+	var wrapped error = ErrRepoNotFound.Wrap(errors.New("no rows"))
+
+	var appErr *AppError
+	assert.True(t, errors.As(wrapped, &appErr))
+	assert.Equal(t, ErrRepoNotFound.Code, appErr.Code)
+}
+
+func TestAppError_SentinelsNotMutated(t *testing.T) {
+	_ = ErrServiceValidation.Wrap(errors.New("boom")).WithField("title", "title is required")
+
+	assert.Nil(t, ErrServiceValidation.Err)
+	assert.Nil(t, ErrServiceValidation.Details)
+}
@@ -13,8 +13,12 @@ var (
 // Service errors
 // ==================
 var (
-	ErrServiceValidation = New("SERVICE_VALIDATION", "service validation failed")
-	ErrServiceConflict   = New("SERVICE_CONFLICT", "resource conflict in service")
+	ErrServiceValidation    = New("SERVICE_VALIDATION", "service validation failed")
+	ErrServiceConflict      = New("SERVICE_CONFLICT", "resource conflict in service")
+	ErrServiceForbidden     = New("SERVICE_FORBIDDEN", "operation is not permitted")
+	ErrServiceQuotaExceeded = New("SERVICE_QUOTA_EXCEEDED", "workspace task quota exceeded")
+	ErrServiceBatchAborted  = New("SERVICE_BATCH_ABORTED", "not applied: another item of the atomic batch failed")
+	ErrServiceTxUnsupported = New("SERVICE_TX_UNSUPPORTED", "storage does not support transactions")
 )
 
 // ==================
@@ -26,11 +30,18 @@ var (
 	ErrTransportForbidden    = New("TRANSPORT_FORBIDDEN", "forbidden")
 	ErrTransportNotFound     = New("TRANSPORT_NOT_FOUND", "resource not found")
 	ErrTransportInternal     = New("TRANSPORT_INTERNAL", "internal server error")
+
+	ErrTransportIdempotencyMismatch   = New("TRANSPORT_IDEMPOTENCY_MISMATCH", "idempotency key is already used with a different request body")
+	ErrTransportIdempotencyInProgress = New("TRANSPORT_IDEMPOTENCY_IN_PROGRESS", "request with this idempotency key is still in progress")
+	ErrTransportPayloadTooLarge       = New("TRANSPORT_PAYLOAD_TOO_LARGE", "request body is too large")
+	ErrTransportRateLimited           = New("TRANSPORT_RATE_LIMITED", "too many requests, retry later")
 )
 
 // ==================
 // Application errors
 // ==================
 var (
-	ErrAppInternal = New("APP_INTERNAL", "internal application error")
+	ErrAppInternal  = New("APP_INTERNAL", "internal application error")
+	ErrAppTimeout   = New("APP_TIMEOUT", "request deadline exceeded")
+	ErrAppCancelled = New("APP_CANCELLED", "request cancelled")
 )
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"strings"
 	"time"
@@ -14,8 +15,14 @@ type DBType int
 const (
 	DBInMemory DBType = iota
 	DBPostgres
+	DBUnknown
 )
 
+// MarshalText — тип хранилища в конфиге и при выводе --print-config пишется строкой.
+func (t DBType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
 func (t DBType) String() string {
 	switch t {
 	case DBInMemory:
@@ -38,12 +45,15 @@ const (
 
 // ServerConfig — конфиг HTTP-сервера.
 type ServerConfig struct {
-	Port string
+	Port            string
+	ShutdownTimeout time.Duration // сколько всего ждать остановки (HTTP, фоновые процессы, экспортеры)
+	ShutdownDelay   time.Duration // пауза после снятия готовности, чтобы балансировщик успел убрать инстанс
 }
 
 // LoggerConfig — конфиг логгера.
 type LoggerConfig struct {
-	Level string
+	Level  string // debug, info, warn, error
+	Format string // text или json
 }
 
 // DBConfig — конфиг для хранилища.
@@ -55,6 +65,88 @@ type DBConfig struct {
 // TaskConfig — конфиг для задач.
 type TaskConfig struct {
 	DefaultDuration time.Duration
+	TrashRetention  time.Duration // Сколько задача хранится в корзине до безвозвратного удаления
+	PurgeInterval   time.Duration // Как часто запускается очистка корзины
+	Timeout         time.Duration // Дедлайн запроса к API задач (кроме выгрузки и загрузки), 0 — без дедлайна
+}
+
+// TenancyConfig — конфиг рабочих пространств (тенантов).
+// -- Квоты ограничивают количество неудаленных задач, 0 — без ограничений.
+type TenancyConfig struct {
+	DefaultQuota int
+	Quotas       map[string]int
+}
+
+// TracingConfig — конфиг трассировки OpenTelemetry.
+// -- Exporter: stdout (читаемый JSON), file (OTLP-JSON построчно), otlp (OTLP/HTTP на коллектор).
+type TracingConfig struct {
+	Enabled     bool
+	Exporter    string
+	File        string  // путь к файлу для exporter=file
+	Endpoint    string  // host:port коллектора для exporter=otlp
+	Insecure    bool    // OTLP без TLS
+	SampleRatio float64 // доля трассируемых запросов, 0..1
+}
+
+// APIConfig — конфиг HTTP API: версии и ключи идемпотентности.
+type APIConfig struct {
+	Legacy      LegacyAPIConfig
+	Idempotency IdempotencyConfig
+	RateLimit   RateLimitConfig
+}
+
+// RateLimitConfig — лимит частоты запросов к API на вызывающего (token bucket).
+// -- RPS — запросов в секунду, 0 — без ограничений; Burst — сколько запросов подряд допустимо сверх RPS.
+type RateLimitConfig struct {
+	RPS   float64
+	Burst int
+}
+
+// IdempotencyConfig — ключи Idempotency-Key для POST /tasks.
+// -- TTL — сколько хранится ответ для повтора; в течение TTL тот же ключ с другим телом отклоняется.
+// -- MaxBody — предел тела запроса с ключом в байтах: тело читается в память целиком, чтобы посчитать хеш.
+type IdempotencyConfig struct {
+	TTL     time.Duration
+	MaxBody int64
+}
+
+// LegacyAPIConfig — маршруты /api без версии, алиас /api/v1.
+// -- Deprecation и Sunset отдаются в одноименных заголовках ответов; нулевое время — заголовок не отправляется
+// --    и в --print-config дата не выводится.
+type LegacyAPIConfig struct {
+	Enabled     bool
+	Deprecation time.Time `yaml:"deprecation,omitempty"` // с какого момента алиас считается устаревшим
+	Sunset      time.Time `yaml:"sunset,omitempty"`      // когда алиас будет отключен
+}
+
+// AuthConfig — конфиг аутентификации.
+// -- Ключи хранятся только в виде sha256-хеша (hex), открытый ключ в конфиг не попадает.
+type AuthConfig struct {
+	Enabled bool
+	APIKeys []APIKeyConfig
+	JWT     JWTConfig
+}
+
+// JWTConfig — конфиг проверки bearer-токенов (HS256 по секрету, RS256 по PEM или JWKS-файлу).
+type JWTConfig struct {
+	Enabled        bool
+	Algorithm      string
+	Secret         string
+	PublicKeyFile  string
+	JWKSFile       string
+	Issuer         string
+	Audience       string
+	Leeway         time.Duration
+	RolesClaim     string
+	WorkspaceClaim string
+}
+
+// APIKeyConfig — API-ключ из конфига.
+type APIKeyConfig struct {
+	Name      string   `mapstructure:"name"`
+	Hash      string   `mapstructure:"hash"`
+	Scopes    []string `mapstructure:"scopes"`
+	Workspace string   `mapstructure:"workspace"` // пусто — ключ не привязан к пространству
 }
 
 // AppConfig — основной конфиг приложения.
@@ -70,17 +162,25 @@ type AppConfig struct {
 	Logger     LoggerConfig
 	DB         DBConfig
 	Task       TaskConfig
+	Auth       AuthConfig
+	Tenancy    TenancyConfig
+	Tracing    TracingConfig
+	API        APIConfig
+
+	// loadProblems — ошибки разбора значений при загрузке (битые длительности, неизвестный db.type),
+	// отдаются вместе с остальными в Validate.
+	loadProblems []string
 }
 
-// ParseDBType — парсинг типа хранилища из строки.
-func ParseDBType(s string) DBType {
+// ParseDBType — парсинг типа хранилища из строки, неизвестный тип — ошибка.
+func ParseDBType(s string) (DBType, error) {
 	switch strings.ToLower(s) {
 	case "inmemory":
-		return DBInMemory
+		return DBInMemory, nil
 	case "postgres":
-		return DBPostgres
+		return DBPostgres, nil
 	default:
-		return DBInMemory
+		return DBUnknown, fmt.Errorf("unknown storage type %q (expected one of: inmemory, postgres)", s)
 	}
 }
 
@@ -101,11 +201,32 @@ func (c *AppConfig) IsDebug() bool {
 	return c.Env == EnvDevelopment || c.Env == EnvTest
 }
 
-// LoadConfig — инициализация и загрузка конфига через viper.
+// LoadOptions — откуда и с какими переопределениями загружать конфиг.
+type LoadOptions struct {
+	// File — явный путь к файлу конфига. Пусто — поиск config.yml в "." и "./configs".
+	File string
+	// Overrides — значения с наивысшим приоритетом (флаги командной строки), ключи как в config.yml: "server.port".
+	Overrides map[string]string
+}
+
+// LoadConfig — инициализация и загрузка конфига через viper из стандартных мест.
+// -- Отсутствие файла не ошибка: используются env и дефолты.
 func LoadConfig() *AppConfig {
-	viper.SetConfigName("config")
-	viper.AddConfigPath(".")
-	viper.AddConfigPath("./configs")
+	cfg, _ := Load(LoadOptions{})
+	return cfg
+}
+
+// Load — загрузка конфига.
+// Приоритет (от высшего к низшему): Overrides (флаги) → переменные окружения APP_* → файл → дефолты.
+// -- Явно указанный файл обязан читаться, иначе ошибка; при поиске по умолчанию его отсутствие допустимо.
+func Load(opts LoadOptions) (*AppConfig, error) {
+	if opts.File != "" {
+		viper.SetConfigFile(opts.File)
+	} else {
+		viper.SetConfigName("config")
+		viper.AddConfigPath(".")
+		viper.AddConfigPath("./configs")
+	}
 	viper.SetEnvPrefix("APP")
 	viper.AutomaticEnv()
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
@@ -113,33 +234,160 @@ func LoadConfig() *AppConfig {
 	// Дефолты
 	viper.SetDefault("env", string(EnvDevelopment))
 	viper.SetDefault("server.port", "8080")
+	viper.SetDefault("server.shutdowntimeout", "15s")
+	viper.SetDefault("server.shutdowndelay", "0s")
 	viper.SetDefault("logger.level", "info")
+	viper.SetDefault("logger.format", "text")
 	viper.SetDefault("db.type", "inmemory")
 	viper.SetDefault("db.dsn", "")
 	viper.SetDefault("task.defaultduration", "5m")
+	viper.SetDefault("task.trashretention", "168h")
+	viper.SetDefault("task.purgeinterval", "1m")
+	viper.SetDefault("task.timeout", "30s")
 	viper.SetDefault("appname", "task-hub")
 	viper.SetDefault("appversion", "1.0.0")
+	viper.SetDefault("auth.enabled", false)
+	viper.SetDefault("auth.jwt.enabled", false)
+	viper.SetDefault("auth.jwt.algorithm", "HS256")
+	viper.SetDefault("auth.jwt.leeway", "30s")
+	viper.SetDefault("auth.jwt.rolesclaim", "roles")
+	viper.SetDefault("auth.jwt.workspaceclaim", "workspace")
+	viper.SetDefault("tenancy.defaultquota", 0)
+	viper.SetDefault("tracing.enabled", false)
+	viper.SetDefault("tracing.exporter", "stdout")
+	viper.SetDefault("tracing.file", "traces.jsonl")
+	viper.SetDefault("tracing.endpoint", "localhost:4318")
+	viper.SetDefault("tracing.insecure", true)
+	viper.SetDefault("tracing.sampleratio", 1.0)
+	viper.SetDefault("api.legacy.enabled", true)
+	viper.SetDefault("api.legacy.deprecation", "")
+	viper.SetDefault("api.legacy.sunset", "")
+	viper.SetDefault("api.idempotency.ttl", "24h")
+	viper.SetDefault("api.idempotency.maxbody", 1<<20)
+	viper.SetDefault("api.ratelimit.rps", 0)
+	viper.SetDefault("api.ratelimit.burst", 20)
 
 	if err := viper.ReadInConfig(); err != nil {
+		if opts.File != "" {
+			return nil, fmt.Errorf("read config %s: %w", opts.File, err)
+		}
 		log.Printf("Config file not found: %v (using env/defaults)", err)
 	}
+	// viper.Set имеет наивысший приоритет и переживает перечитывание файла при горячей перезагрузке.
+	for key, value := range opts.Overrides {
+		viper.Set(key, value)
+	}
+	return fromViper(), nil
+}
+
+// fromViper — сборка AppConfig из текущего состояния viper (файл, env, дефолты).
+// -- Используется и при старте, и при перечитывании файла (см. Watcher).
+func fromViper() *AppConfig {
+	var problems []string
+	var apiKeys []APIKeyConfig
+	if err := viper.UnmarshalKey("auth.apikeys", &apiKeys); err != nil {
+		// Частично разобранный список не используется, иначе к ошибке добавятся проблемы его обрывков
+		problems = append(problems, "auth.apikeys: "+strings.Join(strings.Fields(err.Error()), " "))
+		apiKeys = nil
+	}
+	dbType, err := ParseDBType(viper.GetString("db.type"))
+	if err != nil {
+		problems = append(problems, "db.type: "+err.Error())
+	}
+	duration := func(key string) time.Duration {
+		raw := viper.GetString(key)
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: malformed duration %q (e.g. 30s, 5m, 168h)", key, raw))
+		}
+		return d
+	}
+	// date — дата (2006-01-02) или момент времени (RFC 3339); пусто — нулевое время.
+	date := func(key string) time.Time {
+		raw := viper.GetString(key)
+		if raw == "" {
+			return time.Time{}
+		}
+		for _, layout := range []string{time.DateOnly, time.RFC3339} {
+			if t, err := time.Parse(layout, raw); err == nil {
+				return t
+			}
+		}
+		problems = append(problems, fmt.Sprintf("%s: malformed date %q (e.g. 2026-12-31 or 2026-12-31T00:00:00Z)", key, raw))
+		return time.Time{}
+	}
+
+	quotas := make(map[string]int)
+	for ws := range viper.GetStringMap("tenancy.quotas") {
+		quotas[ws] = viper.GetInt("tenancy.quotas." + ws)
+	}
 
 	return &AppConfig{
 		AppName:    viper.GetString("appname"),
 		AppVersion: viper.GetString("appversion"),
 		Env:        EnvType(viper.GetString("env")),
 		Server: ServerConfig{
-			Port: viper.GetString("server.port"),
+			Port:            viper.GetString("server.port"),
+			ShutdownTimeout: duration("server.shutdowntimeout"),
+			ShutdownDelay:   duration("server.shutdowndelay"),
 		},
 		Logger: LoggerConfig{
-			Level: viper.GetString("logger.level"),
+			Level:  viper.GetString("logger.level"),
+			Format: viper.GetString("logger.format"),
 		},
 		DB: DBConfig{
-			Type: ParseDBType(viper.GetString("db.type")),
+			Type: dbType,
 			DSN:  viper.GetString("db.dsn"),
 		},
 		Task: TaskConfig{
-			DefaultDuration: viper.GetDuration("task.defaultduration"),
+			DefaultDuration: duration("task.defaultduration"),
+			TrashRetention:  duration("task.trashretention"),
+			PurgeInterval:   duration("task.purgeinterval"),
+			Timeout:         duration("task.timeout"),
+		},
+		Auth: AuthConfig{
+			Enabled: viper.GetBool("auth.enabled"),
+			APIKeys: apiKeys,
+			JWT: JWTConfig{
+				Enabled:        viper.GetBool("auth.jwt.enabled"),
+				Algorithm:      viper.GetString("auth.jwt.algorithm"),
+				Secret:         viper.GetString("auth.jwt.secret"),
+				PublicKeyFile:  viper.GetString("auth.jwt.publickeyfile"),
+				JWKSFile:       viper.GetString("auth.jwt.jwksfile"),
+				Issuer:         viper.GetString("auth.jwt.issuer"),
+				Audience:       viper.GetString("auth.jwt.audience"),
+				Leeway:         duration("auth.jwt.leeway"),
+				RolesClaim:     viper.GetString("auth.jwt.rolesclaim"),
+				WorkspaceClaim: viper.GetString("auth.jwt.workspaceclaim"),
+			},
+		},
+		Tenancy: TenancyConfig{
+			DefaultQuota: viper.GetInt("tenancy.defaultquota"),
+			Quotas:       quotas,
+		},
+		Tracing: TracingConfig{
+			Enabled:     viper.GetBool("tracing.enabled"),
+			Exporter:    viper.GetString("tracing.exporter"),
+			File:        viper.GetString("tracing.file"),
+			Endpoint:    viper.GetString("tracing.endpoint"),
+			Insecure:    viper.GetBool("tracing.insecure"),
+			SampleRatio: viper.GetFloat64("tracing.sampleratio"),
+		},
+		API: APIConfig{
+			Legacy: LegacyAPIConfig{
+				Enabled:     viper.GetBool("api.legacy.enabled"),
+				Deprecation: date("api.legacy.deprecation"),
+				Sunset:      date("api.legacy.sunset"),
+			},
+			Idempotency: IdempotencyConfig{
+				TTL:     duration("api.idempotency.ttl"),
+				MaxBody: viper.GetInt64("api.idempotency.maxbody"),
+			},
+			RateLimit: RateLimitConfig{
+				RPS:   viper.GetFloat64("api.ratelimit.rps"),
+				Burst: viper.GetInt("api.ratelimit.burst"),
+			},
 		},
+		loadProblems: problems,
 	}
 }
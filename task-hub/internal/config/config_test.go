@@ -0,0 +1,243 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func validConfig() *AppConfig {
+	return &AppConfig{
+		Env:    EnvDevelopment,
+		Server: ServerConfig{Port: "8080", ShutdownTimeout: 15 * time.Second},
+		Logger: LoggerConfig{Level: "info", Format: "text"},
+		DB:     DBConfig{Type: DBInMemory},
+		Task:   TaskConfig{TrashRetention: time.Hour, PurgeInterval: time.Minute},
+		API:    APIConfig{Idempotency: IdempotencyConfig{TTL: 24 * time.Hour, MaxBody: 1 << 20}},
+	}
+}
+
+func TestValidate_OK(t *testing.T) {
+	assert.NoError(t, validConfig().Validate())
+}
+
+func TestValidate_AggregatesProblems(t *testing.T) {
+	cfg := validConfig()
+	cfg.Env = "debug"
+	cfg.Server.Port = "80a"
+	cfg.Logger.Format = "xml"
+	cfg.DB.Type = DBPostgres
+	cfg.Auth.Enabled = true
+	cfg.Auth.JWT = JWTConfig{Enabled: true, Algorithm: "HS256"}
+	cfg.Auth.APIKeys = []APIKeyConfig{{Name: "ci", Hash: "nothex", Scopes: []string{"admin"}}}
+
+	err := cfg.Validate()
+	var verr *ValidationError
+	if !assert.ErrorAs(t, err, &verr) {
+		return
+	}
+	assert.Len(t, verr.Problems, 6)
+	msg := err.Error()
+	assert.Contains(t, msg, `env: unknown value "debug" (expected one of: dev, prod, test)`)
+	assert.Contains(t, msg, `server.port: invalid port "80a"`)
+	assert.Contains(t, msg, `logger.format: unknown value "xml"`)
+	assert.Contains(t, msg, "db.type: postgres is not implemented yet")
+	assert.Contains(t, msg, "auth.jwt.secret: required for HS256")
+	assert.Contains(t, msg, "auth.apikeys[0].hash")
+}
+
+func TestValidate_JWTRequiresAuth(t *testing.T) {
+	cfg := validConfig()
+	cfg.Auth.JWT = JWTConfig{Enabled: true, Algorithm: "HS256", Secret: "hs256-secret"}
+	assert.EqualError(t, cfg.Validate(), "invalid config:\n  - auth.jwt.enabled: requires auth.enabled")
+
+	cfg.Auth.Enabled = true
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_APIKeyScopes(t *testing.T) {
+	cfg := validConfig()
+	cfg.Auth.APIKeys = []APIKeyConfig{
+		{Name: "ci", Hash: strings.Repeat("a", 64), Scopes: []string{"tasks:read", "tasks:delete"}},
+		{Name: "ops", Hash: strings.Repeat("b", 64), Scopes: []string{"admin"}},
+	}
+	assert.EqualError(t, cfg.Validate(), "invalid config:\n  - "+
+		`auth.apikeys[0].scopes[1]: unknown scope "tasks:delete" (expected one of: tasks:read, tasks:write, admin)`)
+
+	cfg.Auth.APIKeys[0].Scopes = []string{"tasks:read"}
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoadConfig_ParseProblems(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	t.Setenv("APP_DB_TYPE", "mongo")
+	t.Setenv("APP_TASK_PURGEINTERVAL", "every minute")
+
+	cfg := LoadConfig()
+	assert.Equal(t, DBUnknown, cfg.DB.Type)
+	err := cfg.Validate()
+	if !assert.Error(t, err) {
+		return
+	}
+	assert.Contains(t, err.Error(), `db.type: unknown storage type "mongo"`)
+	assert.Contains(t, err.Error(), `task.purgeinterval: malformed duration "every minute"`)
+	// Битая длительность не дает второй ошибки "must be positive"
+	assert.Equal(t, 1, strings.Count(err.Error(), "task.purgeinterval"))
+}
+
+func TestLoad_MalformedAPIKeys(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	path := filepath.Join(t.TempDir(), "task-hub.yml")
+	assert.NoError(t, os.WriteFile(path, []byte("auth:\n  apikeys:\n    - name: ci\n      scopes: admin\n"+
+		"    - just-a-string\n"), 0o644))
+
+	cfg, err := Load(LoadOptions{File: path})
+	if !assert.NoError(t, err) {
+		return
+	}
+	err = cfg.Validate()
+	if !assert.Error(t, err) {
+		return
+	}
+	assert.EqualError(t, err, "invalid config:\n  - auth.apikeys: decoding failed due to the following error(s): "+
+		"'[1]' expected a map, got 'string'")
+}
+
+func TestLoad_QuotaKeysLowerCase(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	path := filepath.Join(t.TempDir(), "task-hub.yml")
+	assert.NoError(t, os.WriteFile(path, []byte("tenancy:\n  quotas:\n    Team-A: 5\n"), 0o644))
+
+	cfg, err := Load(LoadOptions{File: path})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, map[string]int{"team-a": 5}, cfg.Tenancy.Quotas)
+	assert.NoError(t, cfg.Validate())
+
+	// Конфиг, собранный не через viper, регистр ключей сохраняет — такие ключи отвергаются
+	cfg.Tenancy.Quotas = map[string]int{"Team-A": 5}
+	assert.EqualError(t, cfg.Validate(), "invalid config:\n  - "+
+		"tenancy.quotas.Team-A: workspace must be lower case (quotas are matched case-insensitively)")
+}
+
+func TestLoad_LegacyAPIDates(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	t.Setenv("APP_API_LEGACY_DEPRECATION", "2026-11-01")
+	t.Setenv("APP_API_LEGACY_SUNSET", "2026-10-01T00:00:00Z")
+
+	cfg := LoadConfig()
+	assert.True(t, cfg.API.Legacy.Enabled)
+	assert.Equal(t, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), cfg.API.Legacy.Deprecation)
+	err := cfg.Validate()
+	if !assert.Error(t, err) {
+		return
+	}
+	assert.Contains(t, err.Error(), "api.legacy.sunset: must be after api.legacy.deprecation")
+
+	t.Setenv("APP_API_LEGACY_SUNSET", "next spring")
+	assert.Contains(t, LoadConfig().Validate().Error(), `api.legacy.sunset: malformed date "next spring"`)
+}
+
+func TestLoad_IdempotencyTTL(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	assert.Equal(t, 24*time.Hour, LoadConfig().API.Idempotency.TTL)
+	assert.Equal(t, int64(1<<20), LoadConfig().API.Idempotency.MaxBody)
+
+	t.Setenv("APP_API_IDEMPOTENCY_TTL", "0s")
+	t.Setenv("APP_API_IDEMPOTENCY_MAXBODY", "0")
+	err := LoadConfig().Validate()
+	if !assert.Error(t, err) {
+		return
+	}
+	assert.Contains(t, err.Error(), "api.idempotency.ttl: must be positive")
+	assert.Contains(t, err.Error(), "api.idempotency.maxbody: must be positive, got 0")
+}
+
+func TestLoad_Limits(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	cfg := LoadConfig()
+	assert.Equal(t, 30*time.Second, cfg.Task.Timeout)
+	assert.Equal(t, RateLimitConfig{RPS: 0, Burst: 20}, cfg.API.RateLimit)
+
+	t.Setenv("APP_TASK_TIMEOUT", "-1s")
+	t.Setenv("APP_API_RATELIMIT_RPS", "5")
+	t.Setenv("APP_API_RATELIMIT_BURST", "0")
+	err := LoadConfig().Validate()
+	if !assert.Error(t, err) {
+		return
+	}
+	assert.Contains(t, err.Error(), "task.timeout: must not be negative, got -1s")
+	assert.Contains(t, err.Error(), "api.ratelimit.burst: must be positive, got 0")
+
+	// Без лимита burst не проверяется
+	t.Setenv("APP_TASK_TIMEOUT", "0s")
+	t.Setenv("APP_API_RATELIMIT_RPS", "0")
+	assert.NoError(t, LoadConfig().Validate())
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	path := filepath.Join(t.TempDir(), "task-hub.yml")
+	assert.NoError(t, os.WriteFile(path, []byte("server:\n  port: \"7000\"\nlogger:\n  level: warn\nenv: prod\n"), 0o644))
+	t.Setenv("APP_SERVER_PORT", "7001")
+	t.Setenv("APP_LOGGER_LEVEL", "error")
+
+	cfg, err := Load(LoadOptions{File: path, Overrides: map[string]string{"server.port": "7002"}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "7002", cfg.Server.Port)   // флаг важнее env
+	assert.Equal(t, "error", cfg.Logger.Level) // env важнее файла
+	assert.Equal(t, EnvProduction, cfg.Env)    // файл важнее дефолта
+	assert.Equal(t, "1.0.0", cfg.AppVersion)   // дефолт
+}
+
+func TestLoad_MissingExplicitFile(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	_, err := Load(LoadOptions{File: filepath.Join(t.TempDir(), "nope.yml")})
+	assert.Error(t, err)
+}
+
+func TestPrint_RedactsSecrets(t *testing.T) {
+	cfg := validConfig()
+	cfg.DB = DBConfig{Type: DBPostgres, DSN: "postgres://user:secret@db/prod"}
+	cfg.Auth.JWT.Secret = "hs256-secret"
+
+	var buf bytes.Buffer
+	assert.NoError(t, cfg.Print(&buf))
+	out := buf.String()
+	assert.NotContains(t, out, "secret@db")
+	assert.NotContains(t, out, "hs256-secret")
+	assert.Contains(t, out, "type: postgres")
+	assert.Contains(t, out, "trashretention: 1h0m0s")
+	assert.Equal(t, "postgres://user:secret@db/prod", cfg.DB.DSN) // исходный конфиг не тронут
+}
+
+func TestPrint_OmitsUnsetDates(t *testing.T) {
+	cfg := validConfig()
+	cfg.API.Legacy = LegacyAPIConfig{Enabled: true, Sunset: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)}
+
+	var buf bytes.Buffer
+	assert.NoError(t, cfg.Print(&buf))
+	out := buf.String()
+	assert.NotContains(t, out, "0001-01-01")
+	assert.NotContains(t, out, "deprecation:")
+	assert.Contains(t, out, "sunset: 2026-12-31T00:00:00Z")
+}
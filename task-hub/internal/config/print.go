@@ -0,0 +1,32 @@
+package config
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted — заглушка вместо секретов в выводе конфига.
+const redacted = "<redacted>"
+
+// Redacted — копия конфига без секретов (DSN, JWT-секрет), пригодная для вывода и логов.
+func (c *AppConfig) Redacted() *AppConfig {
+	cp := *c
+	if cp.DB.DSN != "" {
+		cp.DB.DSN = redacted
+	}
+	if cp.Auth.JWT.Secret != "" {
+		cp.Auth.JWT.Secret = redacted
+	}
+	return &cp
+}
+
+// Print — вывод действующего конфига в YAML (ключи как в config.yml), секреты скрыты.
+func (c *AppConfig) Print(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c.Redacted()); err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+)
+
+// ValidationError — все найденные проблемы конфига разом, чтобы не чинить их по одной за запуск.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Validate — проверка конфига перед запуском.
+// -- 1. Ошибки разбора из LoadConfig (битые длительности, неизвестный db.type).
+// -- 2. Перечисления: env, logger.level/format, auth.jwt.algorithm, tracing.exporter.
+// -- 3. Порт, обязательные значения (секрет/ключи для JWT), диапазоны.
+// -- 4. Хранилище postgres пока не реализовано: такой конфиг отклоняется, а не падает при старте.
+// -- Возвращает *ValidationError со всеми проблемами или nil.
+func (c *AppConfig) Validate() error {
+	v := &validator{problems: append([]string(nil), c.loadProblems...)}
+
+	v.oneOf("env", string(c.Env), string(EnvDevelopment), string(EnvProduction), string(EnvTest))
+	v.port("server.port", c.Server.Port)
+	v.positive("server.shutdowntimeout", c.Server.ShutdownTimeout.String(), c.Server.ShutdownTimeout > 0)
+	if c.Server.ShutdownDelay < 0 || (c.Server.ShutdownTimeout > 0 && c.Server.ShutdownDelay >= c.Server.ShutdownTimeout) {
+		v.add(fmt.Sprintf("server.shutdowndelay: must be between 0 and server.shutdowntimeout (%s), got %s",
+			c.Server.ShutdownTimeout, c.Server.ShutdownDelay))
+	}
+	v.oneOf("logger.level", strings.ToLower(c.Logger.Level), "debug", "info", "warn", "warning", "error")
+	v.oneOf("logger.format", c.Logger.Format, "text", "json")
+
+	if c.DB.Type == DBPostgres {
+		v.add("db.type: postgres is not implemented yet (expected: inmemory)")
+	}
+
+	v.positive("task.trashretention", c.Task.TrashRetention.String(), c.Task.TrashRetention > 0)
+	v.positive("task.purgeinterval", c.Task.PurgeInterval.String(), c.Task.PurgeInterval > 0)
+	if c.Task.Timeout < 0 {
+		v.add(fmt.Sprintf("task.timeout: must not be negative, got %s", c.Task.Timeout))
+	}
+
+	c.validateAuth(v)
+
+	if c.Tenancy.DefaultQuota < 0 {
+		v.add(fmt.Sprintf("tenancy.defaultquota: must not be negative, got %d", c.Tenancy.DefaultQuota))
+	}
+	for ws, q := range c.Tenancy.Quotas {
+		if ws != strings.ToLower(ws) {
+			v.add(fmt.Sprintf("tenancy.quotas.%s: workspace must be lower case (quotas are matched case-insensitively)", ws))
+		}
+		if q < 0 {
+			v.add(fmt.Sprintf("tenancy.quotas.%s: must not be negative, got %d", ws, q))
+		}
+	}
+
+	if c.Tracing.Enabled {
+		v.oneOf("tracing.exporter", c.Tracing.Exporter, "stdout", "file", "otlp")
+		if c.Tracing.Exporter == "file" && c.Tracing.File == "" {
+			v.add("tracing.file: required when tracing.exporter is file")
+		}
+		if c.Tracing.Exporter == "otlp" && c.Tracing.Endpoint == "" {
+			v.add("tracing.endpoint: required when tracing.exporter is otlp")
+		}
+		if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+			v.add(fmt.Sprintf("tracing.sampleratio: must be between 0 and 1, got %v", c.Tracing.SampleRatio))
+		}
+	}
+
+	legacy := c.API.Legacy
+	if !legacy.Deprecation.IsZero() && !legacy.Sunset.IsZero() && !legacy.Sunset.After(legacy.Deprecation) {
+		v.add("api.legacy.sunset: must be after api.legacy.deprecation")
+	}
+	v.positive("api.idempotency.ttl", c.API.Idempotency.TTL.String(), c.API.Idempotency.TTL > 0)
+	v.positive("api.idempotency.maxbody", strconv.FormatInt(c.API.Idempotency.MaxBody, 10), c.API.Idempotency.MaxBody > 0)
+	if rl := c.API.RateLimit; rl.RPS < 0 {
+		v.add(fmt.Sprintf("api.ratelimit.rps: must not be negative, got %v", rl.RPS))
+	} else if rl.RPS > 0 {
+		v.positive("api.ratelimit.burst", strconv.Itoa(rl.Burst), rl.Burst > 0)
+	}
+
+	return v.err()
+}
+
+func (c *AppConfig) validateAuth(v *validator) {
+	seen := make(map[string]bool)
+	for i, k := range c.Auth.APIKeys {
+		key := fmt.Sprintf("auth.apikeys[%d]", i)
+		switch {
+		case k.Name == "":
+			v.add(key + ".name: required")
+		case seen[k.Name]:
+			v.add(fmt.Sprintf("%s.name: duplicate key name %q", key, k.Name))
+		}
+		seen[k.Name] = true
+		if raw, err := hex.DecodeString(k.Hash); err != nil || len(raw) != 32 {
+			v.add(key + ".hash: must be a sha256 hex digest (64 hex characters)")
+		}
+		if len(k.Scopes) == 0 {
+			v.add(key + ".scopes: at least one scope is required")
+		}
+		for j, s := range k.Scopes {
+			if _, err := auth.ParseScope(s); err != nil {
+				v.add(fmt.Sprintf("%s.scopes[%d]: %v (expected one of: %s, %s, %s)", key, j, err,
+					auth.ScopeTasksRead, auth.ScopeTasksWrite, auth.ScopeAdmin))
+			}
+		}
+	}
+
+	jwt := c.Auth.JWT
+	if !jwt.Enabled {
+		return
+	}
+	// Токены проверяет тот же middleware, что и ключи: без auth.enabled он пропускает всех.
+	if !c.Auth.Enabled {
+		v.add("auth.jwt.enabled: requires auth.enabled")
+	}
+	v.oneOf("auth.jwt.algorithm", jwt.Algorithm, "HS256", "RS256")
+	switch jwt.Algorithm {
+	case "HS256":
+		if jwt.Secret == "" {
+			v.add("auth.jwt.secret: required for HS256")
+		}
+	case "RS256":
+		if jwt.PublicKeyFile == "" && jwt.JWKSFile == "" {
+			v.add("auth.jwt: publickeyfile or jwksfile is required for RS256")
+		}
+	}
+	if jwt.Leeway < 0 {
+		v.add(fmt.Sprintf("auth.jwt.leeway: must not be negative, got %s", jwt.Leeway))
+	}
+}
+
+// validator — накопитель проблем.
+type validator struct {
+	problems []string
+}
+
+func (v *validator) add(problem string) {
+	v.problems = append(v.problems, problem)
+}
+
+func (v *validator) oneOf(key, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.add(fmt.Sprintf("%s: unknown value %q (expected one of: %s)", key, value, strings.Join(allowed, ", ")))
+}
+
+func (v *validator) port(key, value string) {
+	p, err := strconv.Atoi(value)
+	if err != nil || p < 1 || p > 65535 {
+		v.add(fmt.Sprintf("%s: invalid port %q (expected 1-65535)", key, value))
+	}
+}
+
+// positive — значение должно быть положительным; если ключ уже не разобрался, повторно не ругаемся.
+func (v *validator) positive(key, value string, ok bool) {
+	if !ok && !v.has(key) {
+		v.add(fmt.Sprintf("%s: must be positive, got %s", key, value))
+	}
+}
+
+func (v *validator) has(key string) bool {
+	for _, p := range v.problems {
+		if strings.HasPrefix(p, key+":") {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *validator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
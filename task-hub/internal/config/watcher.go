@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"github.com/vagonaizer/workmate/task-hub/pkg/logger"
+)
+
+// Watcher — горячая перезагрузка конфига.
+// -- 1. viper следит за файлом конфига; при изменении конфиг собирается заново и проверяется Validate.
+// -- 2. Невалидный конфиг отбрасывается целиком, продолжает действовать предыдущий.
+// -- 3. Подписчики получают только изменившиеся секции (см. Watch), по порядку подписки.
+// -- 4. Если подписчик не смог применить изменение, уже примененные откатываются к старым значениям.
+// Секции, которые нельзя поменять на лету (порт, хранилище, аутентификация), просто никто не слушает.
+type Watcher struct {
+	mu      sync.Mutex
+	current *AppConfig
+	subs    []subscription
+	logger  *logger.Logger
+}
+
+// subscription — подписчик на секцию конфига (типизированная обертка из Watch).
+type subscription struct {
+	name    string
+	changed func(old, new *AppConfig) bool
+	apply   func(old, new *AppConfig) error
+}
+
+// NewWatcher — конструктор; initial — конфиг, с которым приложение запущено.
+func NewWatcher(initial *AppConfig, log *logger.Logger) *Watcher {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &Watcher{current: initial, logger: log}
+}
+
+// Watch — подписка на секцию конфига.
+// -- section выбирает секцию (например, func(c *AppConfig) LoggerConfig { return c.Logger }),
+// -- apply вызывается со старым и новым значением, только если секция изменилась.
+// -- Ошибка apply отменяет перезагрузку целиком.
+func Watch[T any](w *Watcher, name string, section func(*AppConfig) T, apply func(old, new T) error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subs = append(w.subs, subscription{
+		name: name,
+		changed: func(old, new *AppConfig) bool {
+			return !reflect.DeepEqual(section(old), section(new))
+		},
+		apply: func(old, new *AppConfig) error {
+			return apply(section(old), section(new))
+		},
+	})
+}
+
+// Current — действующий конфиг.
+func (w *Watcher) Current() *AppConfig {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// Start — начать следить за файлом конфига (если конфиг загружен не из файла, ничего не происходит).
+func (w *Watcher) Start() {
+	if viper.ConfigFileUsed() == "" {
+		w.logger.Warn("Файл конфига не найден, горячая перезагрузка отключена")
+		return
+	}
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		if err := w.Apply(fromViper()); err != nil {
+			w.logger.Error("Конфиг %s не применен: %v", e.Name, err)
+		}
+	})
+	viper.WatchConfig()
+	w.logger.Info("Горячая перезагрузка конфига: %s", viper.ConfigFileUsed())
+}
+
+// Apply — проверка и применение нового конфига.
+// -- Возвращает ошибку валидации или ошибку подписчика; в обоих случаях действует старый конфиг.
+func (w *Watcher) Apply(next *AppConfig) error {
+	if err := next.Validate(); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prev := w.current
+
+	applied := make([]subscription, 0, len(w.subs))
+	for _, sub := range w.subs {
+		if !sub.changed(prev, next) {
+			continue
+		}
+		if err := sub.apply(prev, next); err != nil {
+			w.rollback(applied, prev, next)
+			return fmt.Errorf("%s: %w", sub.name, err)
+		}
+		applied = append(applied, sub)
+	}
+	w.current = next
+	if len(applied) > 0 {
+		names := make([]string, len(applied))
+		for i, sub := range applied {
+			names[i] = sub.name
+		}
+		w.logger.Info("Конфиг перезагружен, обновлено: %v", names)
+	}
+	return nil
+}
+
+// rollback — возврат уже примененных секций в обратном порядке.
+func (w *Watcher) rollback(applied []subscription, prev, next *AppConfig) {
+	for i := len(applied) - 1; i >= 0; i-- {
+		if err := applied[i].apply(next, prev); err != nil {
+			w.logger.Error("Не удалось откатить %s: %v", applied[i].name, err)
+		}
+	}
+}
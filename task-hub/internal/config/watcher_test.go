@@ -0,0 +1,99 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcher_AppliesOnlyChangedSections(t *testing.T) {
+	w := NewWatcher(validConfig(), nil)
+	var levels []string
+	quotaCalls := 0
+	Watch(w, "logger.level",
+		func(c *AppConfig) string { return c.Logger.Level },
+		func(_, next string) error { levels = append(levels, next); return nil },
+	)
+	Watch(w, "tenancy",
+		func(c *AppConfig) TenancyConfig { return c.Tenancy },
+		func(_, _ TenancyConfig) error { quotaCalls++; return nil },
+	)
+
+	next := validConfig()
+	next.Logger.Level = "debug"
+	assert.NoError(t, w.Apply(next))
+	assert.Equal(t, []string{"debug"}, levels)
+	assert.Equal(t, 0, quotaCalls)
+	assert.Same(t, next, w.Current())
+}
+
+func TestWatcher_RejectsInvalidConfig(t *testing.T) {
+	initial := validConfig()
+	w := NewWatcher(initial, nil)
+	called := false
+	Watch(w, "logger.level",
+		func(c *AppConfig) string { return c.Logger.Level },
+		func(_, _ string) error { called = true; return nil },
+	)
+
+	next := validConfig()
+	next.Logger.Level = "verbose"
+	var verr *ValidationError
+	assert.ErrorAs(t, w.Apply(next), &verr)
+	assert.False(t, called)
+	assert.Same(t, initial, w.Current())
+}
+
+func TestWatcher_RollbackOnSubscriberError(t *testing.T) {
+	initial := validConfig()
+	w := NewWatcher(initial, nil)
+	level := initial.Logger.Level
+	Watch(w, "logger.level",
+		func(c *AppConfig) string { return c.Logger.Level },
+		func(_, next string) error { level = next; return nil },
+	)
+	Watch(w, "task",
+		func(c *AppConfig) TaskConfig { return c.Task },
+		func(_, _ TaskConfig) error { return errors.New("busy") },
+	)
+
+	next := validConfig()
+	next.Logger.Level = "debug"
+	next.Task.PurgeInterval = time.Hour
+	err := w.Apply(next)
+	assert.ErrorContains(t, err, "task: busy")
+	assert.Equal(t, "info", level) // откатили
+	assert.Same(t, initial, w.Current())
+}
+
+func TestWatcher_ReloadsFile(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	path := filepath.Join(t.TempDir(), "config.yml")
+	assert.NoError(t, os.WriteFile(path, []byte("env: test\nlogger:\n  level: info\n"), 0o644))
+	cfg, err := Load(LoadOptions{File: path})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	w := NewWatcher(cfg, nil)
+	changed := make(chan string, 1)
+	Watch(w, "logger.level",
+		func(c *AppConfig) string { return c.Logger.Level },
+		func(_, next string) error { changed <- next; return nil },
+	)
+	w.Start()
+
+	assert.NoError(t, os.WriteFile(path, []byte("env: test\nlogger:\n  level: debug\n"), 0o644))
+	select {
+	case level := <-changed:
+		assert.Equal(t, "debug", level)
+	case <-time.After(3 * time.Second):
+		t.Fatal("config change was not applied")
+	}
+}
@@ -3,8 +3,37 @@ package models
 import "errors"
 
 var (
-	ErrInvalidTitle    = errors.New("title is required")
-	ErrInvalidStatus   = errors.New("invalid status")
-	ErrInvalidDeadline = errors.New("invalid deadline")
-	ErrInvalidPriority = errors.New("invalid priority")
+	ErrInvalidTitle     = errors.New("title is required")
+	ErrInvalidStatus    = errors.New("invalid status")
+	ErrInvalidDeadline  = errors.New("invalid deadline")
+	ErrInvalidPriority  = errors.New("invalid priority")
+	ErrInvalidWorkspace = errors.New("invalid workspace")
+	ErrInvalidID        = errors.New("invalid id")
+	ErrInvalidTimestamp = errors.New("invalid timestamp")
+	ErrInvalidInterval  = errors.New("invalid work interval")
 )
+
+// FieldOf — к какому полю задачи относится доменная ошибка.
+// -- Нужен сервисному слою, чтобы отдавать клиенту подробности по полям.
+func FieldOf(err error) string {
+	switch {
+	case errors.Is(err, ErrInvalidTitle):
+		return "title"
+	case errors.Is(err, ErrInvalidDeadline):
+		return "deadline"
+	case errors.Is(err, ErrInvalidPriority):
+		return "priority"
+	case errors.Is(err, ErrInvalidStatus):
+		return "status"
+	case errors.Is(err, ErrInvalidWorkspace):
+		return "workspace"
+	case errors.Is(err, ErrInvalidID):
+		return "id"
+	case errors.Is(err, ErrInvalidTimestamp):
+		return "timestamps"
+	case errors.Is(err, ErrInvalidInterval):
+		return "intervals"
+	default:
+		return ""
+	}
+}
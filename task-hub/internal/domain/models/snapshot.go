@@ -0,0 +1,187 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskSnapshot — полное состояние задачи в открытых полях: выгрузка и загрузка задач (экспорт/импорт).
+// -- Duration не хранится: он вычисляется по закрытым интервалам.
+type TaskSnapshot struct {
+	ID          uuid.UUID
+	Title       string
+	Description string
+	Status      TaskStatus
+	PrevStatus  TaskStatus // статус до удаления, только для задач в корзине
+	Priority    TaskPriority
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt time.Time
+	Deadline    time.Time
+	Intervals   []WorkInterval
+	Reason      string
+	DeletedAt   time.Time
+	CreatedBy   string
+	UpdatedBy   string
+	Assignee    string
+	Workspace   string
+}
+
+// Snapshot — состояние задачи для выгрузки.
+func (t *Task) Snapshot() TaskSnapshot {
+	return TaskSnapshot{
+		ID:          t.id,
+		Title:       t.title,
+		Description: t.description,
+		Status:      t.status,
+		PrevStatus:  t.prevStatus,
+		Priority:    t.priority,
+		CreatedAt:   t.createdAt,
+		UpdatedAt:   t.updatedAt,
+		CompletedAt: t.completedAt,
+		Deadline:    t.deadline,
+		Intervals:   t.Intervals(),
+		Reason:      t.reason,
+		DeletedAt:   t.deletedAt,
+		CreatedBy:   t.createdBy,
+		UpdatedBy:   t.updatedBy,
+		Assignee:    t.assignee,
+		Workspace:   t.workspace,
+	}
+}
+
+// TaskFromSnapshot — восстановление задачи из выгрузки с сохранением id и временных меток.
+// -- 1. Проверяются те же инварианты, что обеспечивают сеттеры: заголовок, приоритет (пустой — low), статус.
+// -- 2. Временные метки согласованы: created_at обязателен, остальные не раньше него;
+// --    completed_at — только у завершенной задачи, deleted_at и статус до удаления — только у удаленной.
+// -- 3. Интервалы идут по порядку и не пересекаются; открытым может быть только последний и только у задачи в работе.
+// -- Дедлайн в прошлом допустим: это исторические данные, а не новое значение.
+func TaskFromSnapshot(s TaskSnapshot) (*Task, error) {
+	if s.ID == uuid.Nil {
+		return nil, fmt.Errorf("%w: id is required", ErrInvalidID)
+	}
+	if s.Title == "" {
+		return nil, ErrInvalidTitle
+	}
+	if s.Priority == "" {
+		s.Priority = TaskPriorityLow
+	}
+	if !s.Priority.Valid() {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPriority, s.Priority)
+	}
+	if !s.Status.Valid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidStatus, s.Status)
+	}
+
+	// Состояние задачи без учета корзины: по нему проверяются completed_at и интервалы.
+	state := s.Status
+	if s.Status == TaskStatusDeleted {
+		if !s.PrevStatus.Valid() || s.PrevStatus == TaskStatusDeleted {
+			return nil, fmt.Errorf("%w: deleted task requires a valid previous status, got %q", ErrInvalidStatus, s.PrevStatus)
+		}
+		if s.DeletedAt.IsZero() {
+			return nil, fmt.Errorf("%w: deleted task requires deleted_at", ErrInvalidTimestamp)
+		}
+		state = s.PrevStatus
+	} else {
+		if s.PrevStatus != "" {
+			return nil, fmt.Errorf("%w: previous status is only allowed for deleted tasks", ErrInvalidStatus)
+		}
+		if !s.DeletedAt.IsZero() {
+			return nil, fmt.Errorf("%w: deleted_at is only allowed for deleted tasks", ErrInvalidTimestamp)
+		}
+	}
+
+	if s.CreatedAt.IsZero() {
+		return nil, fmt.Errorf("%w: created_at is required", ErrInvalidTimestamp)
+	}
+	if s.UpdatedAt.IsZero() {
+		s.UpdatedAt = s.CreatedAt
+	}
+	if s.UpdatedAt.Before(s.CreatedAt) {
+		return nil, fmt.Errorf("%w: updated_at is before created_at", ErrInvalidTimestamp)
+	}
+	if !s.DeletedAt.IsZero() && s.DeletedAt.Before(s.CreatedAt) {
+		return nil, fmt.Errorf("%w: deleted_at is before created_at", ErrInvalidTimestamp)
+	}
+	switch {
+	case state == TaskStatusCompleted && s.CompletedAt.IsZero():
+		return nil, fmt.Errorf("%w: completed task requires completed_at", ErrInvalidTimestamp)
+	case state != TaskStatusCompleted && !s.CompletedAt.IsZero():
+		return nil, fmt.Errorf("%w: completed_at is only allowed for completed tasks", ErrInvalidTimestamp)
+	case !s.CompletedAt.IsZero() && s.CompletedAt.Before(s.CreatedAt):
+		return nil, fmt.Errorf("%w: completed_at is before created_at", ErrInvalidTimestamp)
+	}
+
+	duration, err := intervalsDuration(s.Intervals, s.CreatedAt, s.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	intervals := make([]WorkInterval, len(s.Intervals))
+	copy(intervals, s.Intervals)
+	return &Task{
+		id:          s.ID,
+		title:       s.Title,
+		description: s.Description,
+		status:      s.Status,
+		priority:    s.Priority,
+		createdAt:   s.CreatedAt,
+		updatedAt:   s.UpdatedAt,
+		completedAt: s.CompletedAt,
+		duration:    duration,
+		deadline:    s.Deadline,
+		intervals:   intervals,
+		reason:      s.Reason,
+		deletedAt:   s.DeletedAt,
+		prevStatus:  s.PrevStatus,
+		createdBy:   s.CreatedBy,
+		updatedBy:   s.UpdatedBy,
+		assignee:    s.Assignee,
+		workspace:   s.Workspace,
+	}, nil
+}
+
+// intervalsDuration — проверка интервалов выгрузки и суммарное время по закрытым из них.
+// -- У задачи в работе последний интервал открыт, у остальных все закрыты (удаление тоже закрывает интервал).
+func intervalsDuration(intervals []WorkInterval, createdAt time.Time, status TaskStatus) (time.Duration, error) {
+	var duration time.Duration
+	prevEnd := createdAt
+	for i, interval := range intervals {
+		if interval.Start.IsZero() || interval.Start.Before(prevEnd) {
+			return 0, fmt.Errorf("%w: interval %d starts before the task was created or the previous interval ended", ErrInvalidInterval, i)
+		}
+		if interval.End.IsZero() {
+			if i != len(intervals)-1 || status != TaskStatusInProgress {
+				return 0, fmt.Errorf("%w: interval %d is open, only the last interval of an in_progress task may be", ErrInvalidInterval, i)
+			}
+			continue
+		}
+		if interval.End.Before(interval.Start) {
+			return 0, fmt.Errorf("%w: interval %d ends before it starts", ErrInvalidInterval, i)
+		}
+		duration += interval.End.Sub(interval.Start)
+		prevEnd = interval.End
+	}
+	if status == TaskStatusInProgress && (len(intervals) == 0 || !intervals[len(intervals)-1].End.IsZero()) {
+		return 0, fmt.Errorf("%w: in_progress task requires an open interval", ErrInvalidInterval)
+	}
+	return duration, nil
+}
+
+// Valid — известный статус задачи.
+func (s TaskStatus) Valid() bool {
+	switch s {
+	case TaskStatusPending, TaskStatusInProgress, TaskStatusPaused, TaskStatusCompleted,
+		TaskStatusCancelled, TaskStatusFailed, TaskStatusDeleted:
+		return true
+	}
+	return false
+}
+
+// Valid — известный приоритет задачи.
+func (p TaskPriority) Valid() bool {
+	return p == TaskPriorityLow || p == TaskPriorityMedium || p == TaskPriorityHigh
+}
@@ -7,6 +7,14 @@ import (
 	"github.com/google/uuid"
 )
 
+// now — часы модели: все временные метки и длительность работы считаются по ним; тесты подменяют.
+var now = time.Now
+
+// Now — текущее время по часам модели; сервис сравнивает по нему метки задач (срок хранения в корзине).
+func Now() time.Time {
+	return now()
+}
+
 // Алиасы для статусов и приоритов задач
 type (
 	TaskStatus   string
@@ -19,6 +27,7 @@ type (
 const (
 	TaskStatusPending    TaskStatus = "pending"
 	TaskStatusInProgress TaskStatus = "in_progress"
+	TaskStatusPaused     TaskStatus = "paused"
 	TaskStatusCompleted  TaskStatus = "completed"
 	TaskStatusCancelled  TaskStatus = "cancelled"
 	TaskStatusFailed     TaskStatus = "failed"
@@ -35,16 +44,39 @@ const (
 // -- 1. Мы используем неэкспортирумые поля, доступ к ним осуществляется через методы core-logic.
 // -- 2. Мы не используем json-теги, для маршалинга используются ДТО.
 type Task struct {
-	id          uuid.UUID     // ID задачи
-	title       string        // Заголовок-название задачи
-	description string        // Описание задачи, может быть пустым
-	status      TaskStatus    // Статус задачи
-	priority    TaskPriority  // Приоритет задачи
-	createdAt   time.Time     // Время создания задачи
-	updatedAt   time.Time     // Время последнего обновления задачи: статус, описание и т.д.
-	completedAt time.Time     // Время заверешения задачи
-	duration    time.Duration // Сколько ушло времени на выполнение задачи
-	deadline    time.Time     // Крайний срок выполнения задачи
+	id          uuid.UUID      // ID задачи
+	title       string         // Заголовок-название задачи
+	description string         // Описание задачи, может быть пустым
+	status      TaskStatus     // Статус задачи
+	priority    TaskPriority   // Приоритет задачи
+	createdAt   time.Time      // Время создания задачи
+	updatedAt   time.Time      // Время последнего обновления задачи: статус, описание и т.д.
+	completedAt time.Time      // Время заверешения задачи
+	duration    time.Duration  // Сколько ушло времени на выполнение задачи
+	deadline    time.Time      // Крайний срок выполнения задачи
+	intervals   []WorkInterval // Отрезки активной работы над задачей
+	reason      string         // Причина последнего переоткрытия/перезапуска задачи
+	deletedAt   time.Time      // Время перемещения задачи в корзину
+	prevStatus  TaskStatus     // Статус до удаления, восстанавливается при Restore
+	createdBy   string         // Кто создал задачу (subject вызывающего)
+	updatedBy   string         // Кто последним изменил задачу
+	assignee    string         // Исполнитель задачи (subject), может быть пустым
+	workspace   string         // Рабочее пространство (тенант), которому принадлежит задача
+}
+
+// WorkInterval — отрезок времени, в течение которого задача была в работе.
+// -- Незакрытый интервал (задача сейчас в работе) имеет нулевой End.
+type WorkInterval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration — длительность интервала; для открытого интервала считается до текущего момента.
+func (i WorkInterval) Duration() time.Duration {
+	if i.End.IsZero() {
+		return now().Sub(i.Start)
+	}
+	return i.End.Sub(i.Start)
 }
 
 // Конструктор Task.
@@ -66,8 +98,8 @@ func NewTask(title, description string, priority TaskPriority) (*Task, error) {
 		description: description,
 		status:      TaskStatusPending,
 		priority:    priority,
-		createdAt:   time.Now(),
-		updatedAt:   time.Now(),
+		createdAt:   now(),
+		updatedAt:   now(),
 		completedAt: time.Time{},
 		duration:    0,
 		deadline:    time.Time{},
@@ -76,70 +108,190 @@ func NewTask(title, description string, priority TaskPriority) (*Task, error) {
 
 // Сеттеры - методы для изменения состояния task.
 // -- 1. Старт задачи
-// -- 2. Завершение задачи
-// -- 3. Отмена задачи
-// -- 4. Смена статуса на failed
-// -- 5. Удаление задачи
-// -- 6. Установка дедлайна
-// -- 7. Изменение заголовка (названия) задачи
-// -- 8. Задает описание задачи
-// -- 9. Изменение приоритета задачи
+// -- 2. Пауза и возобновление задачи
+// -- 3. Завершение задачи
+// -- 4. Отмена задачи
+// -- 5. Смена статуса на failed
+// -- 6. Переоткрытие и перезапуск завершенной задачи, возврат в ожидание при остановке сервиса
+// -- 7. Удаление задачи в корзину и восстановление из нее
+// -- 8. Установка дедлайна
+// -- 9. Изменение заголовка (названия) задачи
+// -- 10. Задает описание задачи
+// -- 11. Изменение приоритета задачи
+// -- 12. Фиксация автора создания и изменения
+// -- 13. Назначение исполнителя
+// -- 14. Привязка к рабочему пространству
 
 // Start — переводит задачу в статус "в работе".
 // -- 1. Проверяет, что задача в статусе "pending".
-// -- 2. Устанавливает статус "in_progress" и обновляет время обновления.
+// -- 2. Устанавливает статус "in_progress", открывает интервал работы и обновляет время обновления.
 func (t *Task) Start() error {
 	if t.status != TaskStatusPending {
 		return ErrInvalidStatus
 	}
 	t.status = TaskStatusInProgress
-	t.updatedAt = time.Now()
+	t.updatedAt = now()
+	t.openInterval(t.updatedAt)
+	return nil
+}
+
+// Pause — ставит задачу на паузу.
+// -- 1. Проверяет, что задача в статусе "in_progress".
+// -- 2. Закрывает текущий интервал работы и устанавливает статус "paused".
+func (t *Task) Pause() error {
+	if t.status != TaskStatusInProgress {
+		return fmt.Errorf("%w: %v", ErrInvalidStatus, t.status)
+	}
+	t.status = TaskStatusPaused
+	t.updatedAt = now()
+	t.closeInterval(t.updatedAt)
+	return nil
+}
+
+// Resume — возобновляет задачу после паузы.
+// -- 1. Проверяет, что задача в статусе "paused".
+// -- 2. Открывает новый интервал работы и устанавливает статус "in_progress".
+func (t *Task) Resume() error {
+	if t.status != TaskStatusPaused {
+		return fmt.Errorf("%w: %v", ErrInvalidStatus, t.status)
+	}
+	t.status = TaskStatusInProgress
+	t.updatedAt = now()
+	t.openInterval(t.updatedAt)
 	return nil
 }
 
 // Complete — завершает задачу.
 // -- 1. Проверяет, что задача в статусе "in_progress".
-// -- 2. Устанавливает статус "completed", фиксирует время завершения и считает duration.
+// -- 2. Устанавливает статус "completed", фиксирует время завершения.
+// -- 3. Duration считается только по интервалам активной работы (без ожидания и пауз).
 func (t *Task) Complete() error {
 	if t.status != TaskStatusInProgress {
 		return fmt.Errorf("%w: %v", ErrInvalidStatus, t.status)
 	}
 	t.status = TaskStatusCompleted
-	t.completedAt = time.Now()
+	t.completedAt = now()
 	t.updatedAt = t.completedAt
-	t.duration = t.completedAt.Sub(t.createdAt)
+	t.closeInterval(t.completedAt)
 	return nil
 }
 
 // Cancel — отменяет задачу.
-// -- 1. Проверяет, что задача в статусе "pending" или "in_progress".
-// -- 2. Устанавливает статус "cancelled" и обновляет время обновления.
+// -- 1. Проверяет, что задача в статусе "pending", "in_progress" или "paused".
+// -- 2. Устанавливает статус "cancelled", закрывает интервал работы и обновляет время обновления.
 func (t *Task) Cancel() error {
-	if t.status != TaskStatusPending && t.status != TaskStatusInProgress {
+	if t.status != TaskStatusPending && t.status != TaskStatusInProgress && t.status != TaskStatusPaused {
 		return fmt.Errorf("%w: %v", ErrInvalidStatus, t.status)
 	}
 	t.status = TaskStatusCancelled
-	t.updatedAt = time.Now()
+	t.updatedAt = now()
+	t.closeInterval(t.updatedAt)
 	return nil
 }
 
 // Fail — переводит задачу в статус "failed".
 // -- 1. Проверяет, что задача в статусе "in_progress".
-// -- 2. Устанавливает статус "failed" и обновляет время обновления.
+// -- 2. Устанавливает статус "failed", закрывает интервал работы и обновляет время обновления.
 func (t *Task) Fail() error {
 	if t.status != TaskStatusInProgress {
 		return fmt.Errorf("%w: %v", ErrInvalidStatus, t.status)
 	}
 	t.status = TaskStatusFailed
-	t.updatedAt = time.Now()
+	t.updatedAt = now()
+	t.closeInterval(t.updatedAt)
 	return nil
 }
 
-// Delete — переводит задачу в статус "deleted".
-// -- 1. Устанавливает статус "deleted" и обновляет время обновления.
+// Checkpoint — возвращает задачу в работе в "pending" (остановка сервиса посреди работы).
+// -- 1. Проверяет, что задача в статусе "in_progress".
+// -- 2. Закрывает интервал работы и устанавливает статус "pending"; отработанное время сохраняется,
+// следующий Start продолжит работу с него.
+func (t *Task) Checkpoint() error {
+	if t.status != TaskStatusInProgress {
+		return fmt.Errorf("%w: %v", ErrInvalidStatus, t.status)
+	}
+	t.status = TaskStatusPending
+	t.updatedAt = now()
+	t.closeInterval(t.updatedAt)
+	return nil
+}
+
+// Reopen — переоткрывает завершенную или отмененную задачу.
+// -- 1. Проверяет, что задача в статусе "completed" или "cancelled".
+// -- 2. Возвращает статус "pending", сбрасывает время завершения и фиксирует причину.
+// -- 3. Уже отработанные интервалы сохраняются: работа над задачей продолжается.
+func (t *Task) Reopen(reason string) error {
+	if t.status != TaskStatusCompleted && t.status != TaskStatusCancelled {
+		return fmt.Errorf("%w: %v", ErrInvalidStatus, t.status)
+	}
+	t.status = TaskStatusPending
+	t.completedAt = time.Time{}
+	t.reason = reason
+	t.updatedAt = now()
+	return nil
+}
+
+// Retry — перезапускает задачу, завершившуюся ошибкой.
+// -- 1. Проверяет, что задача в статусе "failed".
+// -- 2. Возвращает статус "pending", фиксирует причину.
+// -- 3. Новая попытка считается с нуля: интервалы и duration сбрасываются.
+func (t *Task) Retry(reason string) error {
+	if t.status != TaskStatusFailed {
+		return fmt.Errorf("%w: %v", ErrInvalidStatus, t.status)
+	}
+	t.status = TaskStatusPending
+	t.completedAt = time.Time{}
+	t.duration = 0
+	t.intervals = nil
+	t.reason = reason
+	t.updatedAt = now()
+	return nil
+}
+
+// openInterval — открывает новый интервал работы.
+func (t *Task) openInterval(at time.Time) {
+	t.intervals = append(t.intervals, WorkInterval{Start: at})
+}
+
+// closeInterval — закрывает открытый интервал (если он есть) и пересчитывает duration.
+func (t *Task) closeInterval(at time.Time) {
+	n := len(t.intervals)
+	if n == 0 || !t.intervals[n-1].End.IsZero() {
+		return
+	}
+	t.intervals[n-1].End = at
+	t.duration += at.Sub(t.intervals[n-1].Start)
+}
+
+// Delete — переводит задачу в статус "deleted" (перемещает в корзину).
+// -- 1. Проверяет, что задача еще не удалена.
+// -- 2. Запоминает текущий статус, закрывает интервал работы и фиксирует время удаления.
 func (t *Task) Delete() error {
+	if t.status == TaskStatusDeleted {
+		return fmt.Errorf("%w: %v", ErrInvalidStatus, t.status)
+	}
+	t.prevStatus = t.status
 	t.status = TaskStatusDeleted
-	t.updatedAt = time.Now()
+	t.deletedAt = now()
+	t.updatedAt = t.deletedAt
+	t.closeInterval(t.deletedAt)
+	return nil
+}
+
+// Restore — восстанавливает задачу из корзины.
+// -- 1. Проверяет, что задача в статусе "deleted".
+// -- 2. Возвращает статус, который был до удаления; если задача была в работе — открывает новый интервал.
+func (t *Task) Restore() error {
+	if t.status != TaskStatusDeleted {
+		return fmt.Errorf("%w: %v", ErrInvalidStatus, t.status)
+	}
+	t.status = t.prevStatus
+	t.prevStatus = ""
+	t.deletedAt = time.Time{}
+	t.updatedAt = now()
+	if t.status == TaskStatusInProgress {
+		t.openInterval(t.updatedAt)
+	}
 	return nil
 }
 
@@ -148,14 +300,14 @@ func (t *Task) Delete() error {
 // -- 2. Не позволяет менять дедлайн для завершённых, отменённых и удалённых задач.
 // -- 3. Обновляет поле и время обновления.
 func (t *Task) SetDeadline(deadline time.Time) error {
-	if !deadline.IsZero() && deadline.Before(time.Now()) {
+	if !deadline.IsZero() && deadline.Before(now()) {
 		return fmt.Errorf("%w: deadline must be in the future", ErrInvalidDeadline)
 	}
 	if t.status == TaskStatusCompleted || t.status == TaskStatusCancelled || t.status == TaskStatusDeleted {
 		return fmt.Errorf("%w: cannot set deadline for finished/cancelled/deleted task", ErrInvalidStatus)
 	}
 	t.deadline = deadline
-	t.updatedAt = time.Now()
+	t.updatedAt = now()
 	return nil
 }
 
@@ -167,7 +319,7 @@ func (t *Task) SetTitle(title string) error {
 		return ErrInvalidTitle
 	}
 	t.title = title
-	t.updatedAt = time.Now()
+	t.updatedAt = now()
 	return nil
 }
 
@@ -175,7 +327,7 @@ func (t *Task) SetTitle(title string) error {
 // -- 1. Обновляет поле и время обновления.
 func (t *Task) SetDescription(description string) {
 	t.description = description
-	t.updatedAt = time.Now()
+	t.updatedAt = now()
 }
 
 // SetPriority — изменяет приоритет задачи.
@@ -186,7 +338,39 @@ func (t *Task) SetPriority(priority TaskPriority) error {
 		return fmt.Errorf("%w: %v", ErrInvalidPriority, priority)
 	}
 	t.priority = priority
-	t.updatedAt = time.Now()
+	t.updatedAt = now()
+	return nil
+}
+
+// SetCreatedBy — фиксирует автора задачи, он же считается последним изменившим ее.
+func (t *Task) SetCreatedBy(actor string) {
+	t.createdBy = actor
+	t.updatedBy = actor
+}
+
+// SetUpdatedBy — фиксирует, кто последним изменил задачу.
+func (t *Task) SetUpdatedBy(actor string) {
+	t.updatedBy = actor
+}
+
+// SetAssignee — назначает исполнителя задачи (пустая строка снимает назначение).
+// -- Нельзя менять исполнителя у удаленной задачи.
+func (t *Task) SetAssignee(assignee string) error {
+	if t.status == TaskStatusDeleted {
+		return fmt.Errorf("%w: %v", ErrInvalidStatus, t.status)
+	}
+	t.assignee = assignee
+	t.updatedAt = now()
+	return nil
+}
+
+// SetWorkspace — привязывает задачу к рабочему пространству.
+// -- Привязка делается один раз при создании: перенос задачи между тенантами запрещен.
+func (t *Task) SetWorkspace(workspace string) error {
+	if t.workspace != "" && t.workspace != workspace {
+		return fmt.Errorf("%w: task already belongs to workspace %q", ErrInvalidWorkspace, t.workspace)
+	}
+	t.workspace = workspace
 	return nil
 }
 
@@ -224,10 +408,60 @@ func (t *Task) CompletedAt() time.Time {
 	return t.completedAt
 }
 
+// Duration — суммарное время активной работы по закрытым интервалам.
 func (t *Task) Duration() time.Duration {
 	return t.duration
 }
 
+// DeletedAt — время перемещения задачи в корзину (нулевое, если задача не удалена).
+func (t *Task) DeletedAt() time.Time {
+	return t.deletedAt
+}
+
+// IsDeleted — находится ли задача в корзине.
+func (t *Task) IsDeleted() bool {
+	return t.status == TaskStatusDeleted
+}
+
+// Reason — причина последнего переоткрытия/перезапуска задачи.
+func (t *Task) Reason() string {
+	return t.reason
+}
+
+func (t *Task) CreatedBy() string {
+	return t.createdBy
+}
+
+func (t *Task) UpdatedBy() string {
+	return t.updatedBy
+}
+
+func (t *Task) Assignee() string {
+	return t.assignee
+}
+
+func (t *Task) Workspace() string {
+	return t.workspace
+}
+
+// Intervals — копия интервалов активной работы над задачей.
+func (t *Task) Intervals() []WorkInterval {
+	result := make([]WorkInterval, len(t.intervals))
+	copy(result, t.intervals)
+	return result
+}
+
 func (t *Task) Deadline() time.Time {
 	return t.deadline
 }
+
+// Clone — независимая копия задачи: изменения копии не затрагивают оригинал.
+// -- Нужна хранилищам, которые откатывают изменения (транзакции) или отдают задачи на чтение,
+// --    поскольку сервис меняет задачу на месте.
+func (t *Task) Clone() *Task {
+	c := *t
+	if t.intervals != nil {
+		c.intervals = t.Intervals()
+	}
+	return &c
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -107,3 +108,274 @@ func TestSetPriority(t *testing.T) {
 		t.Error("expected error for invalid priority")
 	}
 }
+
+func TestPauseAndResume(t *testing.T) {
+	task, _ := NewTask("Test", "desc", TaskPriorityLow)
+	assert.Error(t, task.Pause())
+	assert.NoError(t, task.Start())
+	assert.NoError(t, task.Pause())
+	assert.Equal(t, TaskStatusPaused, task.Status())
+	assert.Error(t, task.Complete())
+	assert.NoError(t, task.Resume())
+	assert.Equal(t, TaskStatusInProgress, task.Status())
+	assert.NoError(t, task.Complete())
+
+	intervals := task.Intervals()
+	assert.Len(t, intervals, 2)
+	var worked time.Duration
+	for _, i := range intervals {
+		assert.False(t, i.End.IsZero())
+		worked += i.Duration()
+	}
+	assert.Equal(t, worked, task.Duration())
+}
+
+// fakeClock — подменяет часы модели на время теста; время идет только через advance.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func useFakeClock(t *testing.T) *fakeClock {
+	c := &fakeClock{t: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)}
+	now = func() time.Time { return c.t }
+	t.Cleanup(func() { now = time.Now })
+	return c
+}
+
+func TestDuration_ExcludesPendingAndPaused(t *testing.T) {
+	// step — сколько прошло времени до действия и само действие.
+	type step struct {
+		after  time.Duration
+		action func(*Task) error
+	}
+	tests := []struct {
+		name     string
+		steps    []step
+		duration time.Duration // по закрытым интервалам
+		open     time.Duration // у открытого интервала на момент проверки
+	}{
+		{
+			name:     "ожидание до старта не считается",
+			steps:    []step{{time.Hour, (*Task).Start}, {10 * time.Minute, (*Task).Complete}},
+			duration: 10 * time.Minute,
+		},
+		{
+			name: "пауза не считается",
+			steps: []step{
+				{0, (*Task).Start}, {5 * time.Minute, (*Task).Pause},
+				{time.Hour, (*Task).Resume}, {7 * time.Minute, (*Task).Complete},
+			},
+			duration: 12 * time.Minute,
+		},
+		{
+			name: "несколько пауз",
+			steps: []step{
+				{0, (*Task).Start}, {time.Minute, (*Task).Pause}, {time.Hour, (*Task).Resume},
+				{2 * time.Minute, (*Task).Pause}, {time.Hour, (*Task).Resume}, {3 * time.Minute, (*Task).Fail},
+			},
+			duration: 6 * time.Minute,
+		},
+		{
+			name:     "на паузе — только отработанное",
+			steps:    []step{{0, (*Task).Start}, {4 * time.Minute, (*Task).Pause}, {time.Hour, nil}},
+			duration: 4 * time.Minute,
+		},
+		{
+			name:     "в работе — открытый интервал идет до текущего момента",
+			steps:    []step{{0, (*Task).Start}, {4 * time.Minute, (*Task).Pause}, {time.Hour, (*Task).Resume}, {3 * time.Minute, nil}},
+			duration: 4 * time.Minute,
+			open:     3 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := useFakeClock(t)
+			task, _ := NewTask("Test", "desc", TaskPriorityLow)
+			for _, s := range tt.steps {
+				clock.advance(s.after)
+				if s.action != nil && !assert.NoError(t, s.action(task)) {
+					return
+				}
+			}
+			assert.Equal(t, tt.duration, task.Duration())
+			intervals := task.Intervals()
+			if tt.open > 0 && assert.NotEmpty(t, intervals) {
+				last := intervals[len(intervals)-1]
+				assert.True(t, last.End.IsZero())
+				assert.Equal(t, tt.open, last.Duration())
+			}
+		})
+	}
+}
+
+func TestCancel_FromPaused(t *testing.T) {
+	task, _ := NewTask("Test", "desc", TaskPriorityLow)
+	assert.NoError(t, task.Start())
+	assert.NoError(t, task.Pause())
+	assert.NoError(t, task.Cancel())
+	assert.Equal(t, TaskStatusCancelled, task.Status())
+	assert.False(t, task.Intervals()[0].End.IsZero())
+}
+
+func TestReopen(t *testing.T) {
+	task, _ := NewTask("Test", "desc", TaskPriorityLow)
+	assert.Error(t, task.Reopen("too early"))
+	assert.NoError(t, task.Start())
+	assert.NoError(t, task.Complete())
+	worked := task.Duration()
+
+	assert.NoError(t, task.Reopen("found a bug"))
+	assert.Equal(t, TaskStatusPending, task.Status())
+	assert.True(t, task.CompletedAt().IsZero())
+	assert.Equal(t, "found a bug", task.Reason())
+	assert.Equal(t, worked, task.Duration())
+
+	assert.NoError(t, task.Cancel())
+	assert.NoError(t, task.Reopen("changed our minds"))
+	assert.Equal(t, TaskStatusPending, task.Status())
+}
+
+func TestRetry(t *testing.T) {
+	task, _ := NewTask("Test", "desc", TaskPriorityLow)
+	assert.NoError(t, task.Start())
+	assert.Error(t, task.Retry("not failed yet"))
+	assert.NoError(t, task.Fail())
+
+	assert.NoError(t, task.Retry("flaky dependency"))
+	assert.Equal(t, TaskStatusPending, task.Status())
+	assert.Equal(t, "flaky dependency", task.Reason())
+	assert.Zero(t, task.Duration())
+	assert.Empty(t, task.Intervals())
+	assert.Error(t, task.Reopen("wrong transition"))
+}
+
+func TestCheckpoint(t *testing.T) {
+	clock := useFakeClock(t)
+	task, _ := NewTask("Test", "desc", TaskPriorityLow)
+	assert.Error(t, task.Checkpoint())
+	assert.NoError(t, task.Start())
+	clock.advance(5 * time.Minute)
+
+	// Интервал закрыт в момент остановки, отработанное сохраняется
+	assert.NoError(t, task.Checkpoint())
+	assert.Equal(t, TaskStatusPending, task.Status())
+	assert.Equal(t, 5*time.Minute, task.Duration())
+	if assert.Len(t, task.Intervals(), 1) {
+		assert.Equal(t, clock.t, task.Intervals()[0].End)
+	}
+
+	// После перезапуска работа продолжается с того же времени
+	clock.advance(time.Hour)
+	assert.NoError(t, task.Start())
+	clock.advance(2 * time.Minute)
+	assert.NoError(t, task.Complete())
+	assert.Equal(t, 7*time.Minute, task.Duration())
+
+	assert.NoError(t, task.Reopen("again"))
+	assert.NoError(t, task.Start())
+	assert.NoError(t, task.Pause())
+	assert.Error(t, task.Checkpoint())
+}
+
+func TestDeleteAndRestore(t *testing.T) {
+	task, _ := NewTask("Test", "desc", TaskPriorityLow)
+	assert.Error(t, task.Restore())
+	assert.NoError(t, task.Start())
+	assert.NoError(t, task.Delete())
+	assert.True(t, task.IsDeleted())
+	assert.False(t, task.DeletedAt().IsZero())
+	assert.Error(t, task.Delete())
+
+	assert.NoError(t, task.Restore())
+	assert.Equal(t, TaskStatusInProgress, task.Status())
+	assert.True(t, task.DeletedAt().IsZero())
+	assert.Len(t, task.Intervals(), 2)
+}
+
+func TestClone(t *testing.T) {
+	task, _ := NewTask("Test", "desc", TaskPriorityLow)
+	assert.NoError(t, task.Start())
+
+	clone := task.Clone()
+	assert.NoError(t, clone.Complete())
+	assert.NoError(t, clone.SetTitle("Changed"))
+
+	assert.Equal(t, TaskStatusInProgress, task.Status())
+	assert.Equal(t, "Test", task.Title())
+	assert.True(t, task.Intervals()[0].End.IsZero())
+	assert.Equal(t, task.ID(), clone.ID())
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	task, _ := NewTask("Test", "desc", TaskPriorityHigh)
+	_ = task.SetWorkspace("acme")
+	task.SetCreatedBy("alice")
+	assert.NoError(t, task.Start())
+	assert.NoError(t, task.Pause())
+	assert.NoError(t, task.Delete())
+
+	restored, err := TaskFromSnapshot(task.Snapshot())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, task.Snapshot(), restored.Snapshot())
+	assert.Equal(t, task.Duration(), restored.Duration())
+
+	// Восстановленная задача живет по тем же правилам: Restore возвращает статус до удаления
+	assert.NoError(t, restored.Restore())
+	assert.Equal(t, TaskStatusPaused, restored.Status())
+}
+
+func TestTaskFromSnapshot_Validation(t *testing.T) {
+	created := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	valid := func() TaskSnapshot {
+		return TaskSnapshot{ID: uuid.New(), Title: "t", Status: TaskStatusPending, CreatedAt: created}
+	}
+
+	task, err := TaskFromSnapshot(valid())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, TaskPriorityLow, task.Priority())
+	assert.Equal(t, created, task.UpdatedAt())
+
+	// Дедлайн в прошлом допустим, интервалы дают duration
+	s := valid()
+	s.Status = TaskStatusCompleted
+	s.Deadline = created.Add(time.Hour)
+	s.CompletedAt = created.Add(3 * time.Hour)
+	s.Intervals = []WorkInterval{{Start: created, End: created.Add(time.Hour)}, {Start: created.Add(2 * time.Hour), End: created.Add(3 * time.Hour)}}
+	task, err = TaskFromSnapshot(s)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 2*time.Hour, task.Duration())
+
+	cases := map[string]struct {
+		mutate func(*TaskSnapshot)
+		want   error
+	}{
+		"no id":              {func(s *TaskSnapshot) { s.ID = uuid.Nil }, ErrInvalidID},
+		"no title":           {func(s *TaskSnapshot) { s.Title = "" }, ErrInvalidTitle},
+		"bad priority":       {func(s *TaskSnapshot) { s.Priority = "urgent" }, ErrInvalidPriority},
+		"bad status":         {func(s *TaskSnapshot) { s.Status = "done" }, ErrInvalidStatus},
+		"deleted no prev":    {func(s *TaskSnapshot) { s.Status, s.DeletedAt = TaskStatusDeleted, created }, ErrInvalidStatus},
+		"prev not deleted":   {func(s *TaskSnapshot) { s.PrevStatus = TaskStatusPending }, ErrInvalidStatus},
+		"no created_at":      {func(s *TaskSnapshot) { s.CreatedAt = time.Time{} }, ErrInvalidTimestamp},
+		"updated before":     {func(s *TaskSnapshot) { s.UpdatedAt = created.Add(-time.Second) }, ErrInvalidTimestamp},
+		"completed no time":  {func(s *TaskSnapshot) { s.Status = TaskStatusCompleted }, ErrInvalidTimestamp},
+		"pending with time":  {func(s *TaskSnapshot) { s.CompletedAt = created }, ErrInvalidTimestamp},
+		"in_progress closed": {func(s *TaskSnapshot) { s.Status = TaskStatusInProgress }, ErrInvalidInterval},
+		"open not last":      {func(s *TaskSnapshot) { s.Intervals = []WorkInterval{{Start: created}} }, ErrInvalidInterval},
+		"interval before":    {func(s *TaskSnapshot) { s.Intervals = []WorkInterval{{Start: created.Add(-time.Hour), End: created}} }, ErrInvalidInterval},
+		"intervals overlap": {func(s *TaskSnapshot) {
+			s.Intervals = []WorkInterval{{Start: created, End: created.Add(time.Hour)}, {Start: created.Add(time.Minute), End: created.Add(2 * time.Hour)}}
+		}, ErrInvalidInterval},
+	}
+	for name, tc := range cases {
+		s := valid()
+		tc.mutate(&s)
+		_, err := TaskFromSnapshot(s)
+		assert.ErrorIs(t, err, tc.want, name)
+	}
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"github.com/google/uuid"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+)
+
+// TaskMetrics — наблюдатель за жизненным циклом задач, вызывается сервисом после успешной записи.
+// Реализация сама помнит последнее известное состояние задачи, чтобы считать переходы.
+type TaskMetrics interface {
+	// TaskSaved фиксирует текущее состояние задачи (создание или изменение).
+	TaskSaved(task *models.Task)
+
+	// TaskRemoved фиксирует безвозвратное удаление задачи.
+	// Задача определяется парой (пространство, id): один и тот же id может быть импортирован в разные пространства.
+	TaskRemoved(workspace string, id uuid.UUID)
+}
@@ -1,6 +1,10 @@
 package ports
 
 import (
+	"bytes"
+	"context"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
 )
@@ -16,16 +20,61 @@ import (
 	отдельная директория под интерфейсы - отличная практика.
 */
 
+// Все операции репозитория ограничены рабочим пространством (тенантом):
+// задача другого пространства для вызывающего не существует.
+// Реализации обязаны учитывать отмену и дедлайн ctx и возвращать ctx.Err().
 type TaskRepository interface {
-	// Save сохраняет задачу (создаёт новую или обновляет существующую).
-	Save(task *models.Task) error
+	// Save сохраняет задачу (создаёт новую или обновляет существующую) в её рабочем пространстве.
+	Save(ctx context.Context, task *models.Task) error
+
+	// GetByID возвращает задачу рабочего пространства по её идентификатору.
+	GetByID(ctx context.Context, workspace string, id uuid.UUID) (*models.Task, error)
+
+	// Delete удаляет задачу рабочего пространства по идентификатору.
+	Delete(ctx context.Context, workspace string, id uuid.UUID) error
+
+	// List возвращает все задачи рабочего пространства.
+	// Пустой workspace — задачи всех пространств (только для системных процессов, например очистки корзины).
+	List(ctx context.Context, workspace string) ([]*models.Task, error)
 
-	// GetByID возвращает задачу по её идентификатору.
-	GetByID(id uuid.UUID) (*models.Task, error)
+	// Count возвращает количество неудаленных задач рабочего пространства (для квот).
+	Count(ctx context.Context, workspace string) (int, error)
 
-	// Delete удаляет задачу по идентификатору.
-	Delete(id uuid.UUID) error
+	// ListPage возвращает до limit задач рабочего пространства (включая корзину), следующих за курсором after,
+	// по времени создания, затем по id. Нулевой курсор — с начала; страница короче limit — последняя.
+	// Нужен для выгрузки пространства по частям, без загрузки всех задач в память.
+	ListPage(ctx context.Context, workspace string, after TaskCursor, limit int) ([]*models.Task, error)
+}
+
+// TaskCursor — позиция в порядке ListPage: время создания и id последней полученной задачи.
+type TaskCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// CursorOf — курсор, с которого ListPage продолжит после задачи t.
+func CursorOf(t *models.Task) TaskCursor {
+	return TaskCursor{CreatedAt: t.CreatedAt(), ID: t.ID()}
+}
+
+// Compare — порядок позиций: -1, если c раньше o, 0 — та же позиция, +1 — позже.
+func (c TaskCursor) Compare(o TaskCursor) int {
+	if r := c.CreatedAt.Compare(o.CreatedAt); r != 0 {
+		return r
+	}
+	return bytes.Compare(c.ID[:], o.ID[:])
+}
+
+// HealthChecker — необязательный интерфейс хранилища для проверки готовности (/readyz).
+// Реализует его репозиторий, у которого есть внешнее соединение (БД и т.п.).
+type HealthChecker interface {
+	// Ping проверяет, что хранилище доступно.
+	Ping(ctx context.Context) error
+}
 
-	// List возвращает все задачи.
-	List() ([]*models.Task, error)
+// Transactor — необязательный интерфейс хранилища с транзакциями (режим "все или ничего" в пакетных операциях).
+// -- fn работает с репозиторием tx, видящим собственные изменения, и вызывает его с переданным ей ctx;
+// -- при ошибке fn изменения отбрасываются, иначе фиксируются целиком. Ошибка fn возвращается как есть.
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context, tx TaskRepository) error) error
 }
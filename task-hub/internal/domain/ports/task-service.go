@@ -1,25 +1,87 @@
 package ports
 
 import (
+	"context"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
 )
 
+// TaskService — сценарии работы с задачами для любого транспорта.
+// -- ctx каждой операции несет вызывающего (auth.PrincipalFrom) и рабочее пространство (auth.WorkspaceFrom),
+// а его отмена и дедлайн доходят до хранилища.
 type TaskService interface {
-	CreateTask(title, description string, priority models.TaskPriority, deadline time.Time) (*models.Task, error)
-	GetTask(id uuid.UUID) (*models.Task, error)
-	DeleteTask(id uuid.UUID) error
-	ListTasks() ([]*models.Task, error)
-
-	StartTask(id uuid.UUID) error
-	CompleteTask(id uuid.UUID) error
-	CancelTask(id uuid.UUID) error
-	FailTask(id uuid.UUID) error
-
-	UpdateTitle(id uuid.UUID, title string) error
-	UpdateDescription(id uuid.UUID, description string) error
-	UpdatePriority(id uuid.UUID, priority models.TaskPriority) error
-	UpdateDeadline(id uuid.UUID, deadline time.Time) error
+	CreateTask(ctx context.Context, title, description string, priority models.TaskPriority, deadline time.Time) (*models.Task, error)
+	GetTask(ctx context.Context, id uuid.UUID) (*models.Task, error)
+	DeleteTask(ctx context.Context, id uuid.UUID) error
+	RestoreTask(ctx context.Context, id uuid.UUID) error
+	PurgeDeleted(ctx context.Context, retention time.Duration) (int, error)
+	CheckpointRunning(ctx context.Context) (int, error)
+	ListTasks(ctx context.Context, includeDeleted bool) ([]*models.Task, error)
+
+	StartTask(ctx context.Context, id uuid.UUID) error
+	PauseTask(ctx context.Context, id uuid.UUID) error
+	ResumeTask(ctx context.Context, id uuid.UUID) error
+	CompleteTask(ctx context.Context, id uuid.UUID) error
+	CancelTask(ctx context.Context, id uuid.UUID) error
+	FailTask(ctx context.Context, id uuid.UUID) error
+	ReopenTask(ctx context.Context, id uuid.UUID, reason string) error
+	RetryTask(ctx context.Context, id uuid.UUID, reason string) error
+
+	UpdateTitle(ctx context.Context, id uuid.UUID, title string) error
+	UpdateDescription(ctx context.Context, id uuid.UUID, description string) error
+	UpdatePriority(ctx context.Context, id uuid.UUID, priority models.TaskPriority) error
+	UpdateDeadline(ctx context.Context, id uuid.UUID, deadline time.Time) error
+	AssignTask(ctx context.Context, id uuid.UUID, assignee string) error
+
+	ChangeStatus(ctx context.Context, id uuid.UUID, status models.TaskStatus, reason string) error
+	CreateTasks(ctx context.Context, drafts []TaskDraft, atomic bool) ([]BulkResult, error)
+	ChangeStatuses(ctx context.Context, changes []StatusChange, atomic bool) ([]BulkResult, error)
+
+	ExportTasks(ctx context.Context, filter TaskFilter, fn func(*models.Task) error) error
+	ImportTask(ctx context.Context, snapshot models.TaskSnapshot) (*models.Task, error)
+}
+
+// TaskFilter — отбор задач для выгрузки; пустые списки — без ограничения по полю.
+// -- Задачи из корзины попадают только при IncludeDeleted или явном статусе "deleted" в Statuses.
+type TaskFilter struct {
+	Statuses       []models.TaskStatus
+	Priorities     []models.TaskPriority
+	IncludeDeleted bool
+}
+
+// Match — подходит ли задача под фильтр.
+func (f TaskFilter) Match(t *models.Task) bool {
+	if len(f.Statuses) > 0 {
+		if !slices.Contains(f.Statuses, t.Status()) {
+			return false
+		}
+	} else if t.IsDeleted() && !f.IncludeDeleted {
+		return false
+	}
+	return len(f.Priorities) == 0 || slices.Contains(f.Priorities, t.Priority())
+}
+
+// TaskDraft — данные одной задачи в пакетном создании.
+type TaskDraft struct {
+	Title       string
+	Description string
+	Priority    models.TaskPriority
+	Deadline    time.Time
+}
+
+// StatusChange — перевод одной задачи в новый статус в пакетной операции.
+type StatusChange struct {
+	ID     uuid.UUID
+	Status models.TaskStatus
+	Reason string // для переоткрытия/перезапуска
+}
+
+// BulkResult — результат одного элемента пакетной операции (в порядке элементов запроса):
+// задача после операции или ошибка этого элемента.
+type BulkResult struct {
+	Task *models.Task
+	Err  error
 }
@@ -0,0 +1,169 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/app"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/config"
+)
+
+func TestAuthE2E(t *testing.T) {
+	cfg := config.LoadConfig()
+	cfg.Auth.Enabled = true
+	cfg.Auth.APIKeys = []config.APIKeyConfig{
+		{Name: "reader", Hash: auth.HashKey("reader-key"), Scopes: []string{"tasks:read"}},
+		{Name: "root", Hash: auth.HashKey("root-key"), Scopes: []string{"admin"}},
+	}
+	application := newApp(t, cfg)
+	ts := httptest.NewServer(application.Engine)
+	defer ts.Close()
+
+	do := func(method, path, key string, body []byte) *http.Response {
+		req, _ := http.NewRequest(method, ts.URL+path, bytes.NewReader(body))
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		return resp
+	}
+
+	// 1. Без ключа и с неверным ключом — 401
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/v1/tasks", "", nil).StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/v1/tasks", "wrong", nil).StatusCode)
+
+	// 2. tasks:read может читать, но не писать
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/v1/tasks", "reader-key", nil).StatusCode)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/api/v1/tasks", "reader-key", []byte(`{"title":"x"}`)).StatusCode)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/api/v1/admin/keys", "reader-key", nil).StatusCode)
+
+	// 3. admin создает ключ с tasks:write, и им можно создавать задачи
+	resp := do(http.MethodPost, "/api/v1/admin/keys", "root-key", []byte(`{"name":"writer","scopes":["tasks:write"]}`))
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	respBody, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	var created struct {
+		Key string `json:"key"`
+	}
+	_ = json.Unmarshal(respBody, &created)
+	assert.NotEmpty(t, created.Key)
+
+	resp = do(http.MethodPost, "/api/v1/tasks", created.Key, []byte(`{"title":"by writer"}`))
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	respBody, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	var task taskResponse
+	_ = json.Unmarshal(respBody, &task)
+
+	// 4. Удаление в корзину — tasks:write, читатель не может
+	assert.Equal(t, http.StatusForbidden, do(http.MethodDelete, "/api/v1/tasks/"+task.ID, "reader-key", nil).StatusCode)
+	assert.Equal(t, http.StatusNoContent, do(http.MethodDelete, "/api/v1/tasks/"+task.ID, created.Key, nil).StatusCode)
+
+	// 5. Отозванный ключ больше не работает
+	assert.Equal(t, http.StatusNoContent, do(http.MethodDelete, "/api/v1/admin/keys/writer", "root-key", nil).StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/v1/tasks", created.Key, nil).StatusCode)
+}
+
+func TestAuthE2E_JWT(t *testing.T) {
+	cfg := config.LoadConfig()
+	cfg.Auth.Enabled = true
+	cfg.Auth.JWT = config.JWTConfig{
+		Enabled:    true,
+		Algorithm:  "HS256",
+		Secret:     "e2e-secret",
+		Audience:   "task-hub",
+		RolesClaim: "roles",
+	}
+	application := newApp(t, cfg)
+	ts := httptest.NewServer(application.Engine)
+	defer ts.Close()
+
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   "alice",
+		"aud":   "task-hub",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"member"},
+	}).SignedString([]byte("e2e-secret"))
+
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/api/v1/tasks", bytes.NewReader([]byte(`{"title":"from sso"}`)))
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	respBody, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	var created struct {
+		CreatedBy string `json:"created_by"`
+		UpdatedBy string `json:"updated_by"`
+	}
+	_ = json.Unmarshal(respBody, &created)
+	assert.Equal(t, "alice", created.CreatedBy)
+	assert.Equal(t, "alice", created.UpdatedBy)
+
+	req, _ = http.NewRequest(http.MethodGet, ts.URL+"/api/v1/tasks", nil)
+	req.Header.Set("Authorization", "Bearer not-a-token")
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func TestAuthE2E_Disabled(t *testing.T) {
+	cfg := config.LoadConfig()
+	cfg.Auth.Enabled = false
+	application := newApp(t, cfg)
+	ts := httptest.NewServer(application.Engine)
+	defer ts.Close()
+
+	// Без аутентификации управлять ключами некому: маршрутов /admin нет, задачи доступны
+	for _, path := range []string{"/api/v1/admin/keys", "/api/admin/keys"} {
+		resp, err := http.Get(ts.URL + path)
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode, path)
+		}
+	}
+	resp, err := http.Post(ts.URL+"/api/v1/admin/keys", "application/json", bytes.NewReader([]byte(`{"name":"x","scopes":["admin"]}`)))
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+	resp, err = http.Get(ts.URL + "/api/v1/tasks")
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+}
+
+func TestAuthE2E_UnknownScope(t *testing.T) {
+	cfg := config.LoadConfig()
+	cfg.Auth.Enabled = true
+	cfg.Auth.APIKeys = []config.APIKeyConfig{{Name: "ci", Hash: auth.HashKey("ci-key"), Scopes: []string{"tasks:delete"}}}
+
+	// Опечатка в скоупе не дает запуститься: ни проверка конфига, ни сборка приложения в обход нее
+	assert.ErrorContains(t, cfg.Validate(), `auth.apikeys[0].scopes[0]: unknown scope "tasks:delete"`)
+	application, err := app.NewApp(cfg)
+	assert.Nil(t, application)
+	assert.ErrorContains(t, err, `auth.apikeys ci: unknown scope "tasks:delete"`)
+}
+
+func TestAuthE2E_JWTMisconfigured(t *testing.T) {
+	cfg := config.LoadConfig()
+	cfg.Auth.Enabled = true
+	cfg.Auth.JWT = config.JWTConfig{Enabled: true, Algorithm: "RS256", PublicKeyFile: filepath.Join(t.TempDir(), "missing.pem")}
+
+	// Ключ не читается — приложение не собирается, ошибка возвращается, а не паникует
+	application, err := app.NewApp(cfg)
+	assert.Nil(t, application)
+	assert.ErrorContains(t, err, "auth.jwt")
+}
@@ -0,0 +1,123 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/config"
+)
+
+type bulkResponse struct {
+	Results []struct {
+		Index  int           `json:"index"`
+		Status int           `json:"status"`
+		Task   *taskResponse `json:"task"`
+		Error  *struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	} `json:"results"`
+	Succeeded int  `json:"succeeded"`
+	Failed    int  `json:"failed"`
+	Atomic    bool `json:"atomic"`
+}
+
+func TestBulkE2E(t *testing.T) {
+	cfg := config.LoadConfig()
+	application := newApp(t, cfg)
+	ts := httptest.NewServer(application.Engine)
+	defer ts.Close()
+
+	post := func(path, body string) (int, bulkResponse) {
+		resp, err := http.Post(ts.URL+path, "application/json", bytes.NewReader([]byte(body)))
+		assert.NoError(t, err)
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		var r bulkResponse
+		_ = json.Unmarshal(data, &r)
+		return resp.StatusCode, r
+	}
+	count := func() int {
+		resp, err := http.Get(ts.URL + "/api/v1/tasks")
+		assert.NoError(t, err)
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		var list struct {
+			Tasks []taskResponse `json:"tasks"`
+		}
+		_ = json.Unmarshal(data, &list)
+		return len(list.Tasks)
+	}
+
+	// 1. Атомарное создание с невалидной задачей — не создается ничего
+	status, r := post("/api/v1/tasks/bulk", `{"atomic":true,"tasks":[{"title":"a"},{"title":""}]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	if !assert.Len(t, r.Results, 2) {
+		return
+	}
+	assert.Equal(t, http.StatusFailedDependency, r.Results[0].Status)
+	assert.Equal(t, "SERVICE_BATCH_ABORTED", r.Results[0].Error.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, r.Results[1].Status)
+	assert.Equal(t, 0, count())
+
+	// 2. То же без atomic — создается валидная часть, 207
+	status, r = post("/api/v1/tasks/bulk", `{"tasks":[{"title":"a"},{"title":""},{"title":"c","priority":"high"}]}`)
+	assert.Equal(t, http.StatusMultiStatus, status)
+	assert.Equal(t, 2, r.Succeeded)
+	assert.Equal(t, 1, r.Failed)
+	if !assert.Len(t, r.Results, 3) || !assert.NotNil(t, r.Results[2].Task) {
+		return
+	}
+	assert.Equal(t, http.StatusCreated, r.Results[0].Status)
+	assert.Equal(t, "high", r.Results[2].Task.Priority)
+	assert.Equal(t, 2, count())
+	a, c := r.Results[0].Task.ID, r.Results[2].Task.ID
+
+	// 3. Все успешно — 201
+	status, r = post("/api/v1/tasks/bulk", `{"atomic":true,"tasks":[{"title":"d"}]}`)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, 1, r.Succeeded)
+	assert.Equal(t, 3, count())
+
+	// 4. Пакетная смена статусов: невалидный id, недопустимый переход, успех
+	status, r = post("/api/v1/tasks/bulk/status", `{"items":[
+		{"id":"not-a-uuid","status":"cancelled"},
+		{"id":"`+a+`","status":"completed"},
+		{"id":"`+c+`","status":"in_progress"}]}`)
+	assert.Equal(t, http.StatusMultiStatus, status)
+	if !assert.Len(t, r.Results, 3) {
+		return
+	}
+	assert.Equal(t, http.StatusBadRequest, r.Results[0].Status)
+	assert.Equal(t, http.StatusConflict, r.Results[1].Status)
+	assert.Equal(t, http.StatusOK, r.Results[2].Status)
+	assert.Equal(t, "in_progress", r.Results[2].Task.Status)
+
+	// 5. Атомарно с ошибкой — ни одна задача не отменена
+	status, _ = post("/api/v1/tasks/bulk/status", `{"atomic":true,"items":[
+		{"id":"`+a+`","status":"cancelled"},
+		{"id":"`+c+`","status":"pending"}]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	resp, err := http.Get(ts.URL + "/api/v1/tasks/" + a + "/status")
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Contains(t, string(data), "pending")
+
+	status, r = post("/api/v1/tasks/bulk/status", `{"atomic":true,"items":[
+		{"id":"`+a+`","status":"cancelled"},
+		{"id":"`+c+`","status":"completed"}]}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 2, r.Succeeded)
+
+	// 6. Пустой пакет и больше 100 элементов — ошибка запроса целиком
+	status, _ = post("/api/v1/tasks/bulk", `{"tasks":[]}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = post("/api/v1/tasks/bulk", `{"tasks":[`+strings.Repeat(`{"title":"x"},`, 100)+`{"title":"x"}]}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
@@ -0,0 +1,170 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/app"
+	"github.com/vagonaizer/workmate/task-hub/internal/config"
+)
+
+type readinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+func TestHealthE2E(t *testing.T) {
+	cfg := config.LoadConfig()
+	application := newApp(t, cfg)
+	ts := httptest.NewServer(application.Engine)
+	defer ts.Close()
+
+	readyz := func() (int, readinessResponse) {
+		resp, err := http.Get(ts.URL + "/readyz")
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		var r readinessResponse
+		_ = json.Unmarshal(body, &r)
+		return resp.StatusCode, r
+	}
+
+	// 1. Процесс жив всегда
+	resp, err := http.Get(ts.URL + "/healthz")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// 2. Пока фоновая очистка не запущена — не готов
+	status, r := readyz()
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "ok", r.Checks["repository"])
+	assert.NotEqual(t, "ok", r.Checks["purger"])
+
+	// 3. После запуска — готов
+	application.Purger.Start()
+	defer application.Purger.Stop()
+	status, r = readyz()
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", r.Status)
+
+	// 4. Начало остановки — сразу не готов
+	application.Health.SetShuttingDown()
+	status, r = readyz()
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Contains(t, r.Checks, "shutdown")
+
+	// 5. Информация о сборке
+	resp, err = http.Get(ts.URL + "/version")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	var v map[string]string
+	_ = json.Unmarshal(body, &v)
+	assert.Equal(t, cfg.AppName, v["app_name"])
+	assert.Equal(t, cfg.AppVersion, v["version"])
+	assert.NotEmpty(t, v["commit"])
+}
+
+func TestStartupE2E_RefusesPostgres(t *testing.T) {
+	cfg := config.LoadConfig()
+	cfg.DB = config.DBConfig{Type: config.DBPostgres, DSN: "postgres://user:secret@db/tasks"}
+
+	// Конфиг не проходит проверку, а если ее обойти — приложение не собирается вместо паники
+	err := cfg.Validate()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "db.type: postgres is not implemented yet")
+	}
+	application, err := app.NewApp(cfg)
+	assert.Nil(t, application)
+	assert.ErrorContains(t, err, "postgres is not implemented yet")
+
+	cfg.DB.Type = config.DBUnknown
+	_, err = app.NewApp(cfg)
+	assert.ErrorContains(t, err, "unknown type")
+}
+
+func TestShutdownE2E(t *testing.T) {
+	cfg := config.LoadConfig()
+	application := newApp(t, cfg)
+	ts := httptest.NewServer(application.Engine)
+	defer ts.Close()
+
+	// Задача в работе на момент остановки
+	resp, err := http.Post(ts.URL+"/api/v1/tasks", "application/json", strings.NewReader(`{"title":"running"}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	var task taskResponse
+	_ = json.NewDecoder(resp.Body).Decode(&task)
+	resp.Body.Close()
+	req, _ := http.NewRequest(http.MethodPatch, ts.URL+"/api/v1/tasks/"+task.ID+"/status", strings.NewReader(`{"status":"in_progress"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = http.DefaultClient.Do(req)
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+
+	application.Purger.Start()
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	assert.NoError(t, application.Shutdown(ctx))
+	assert.False(t, application.Purger.Running())
+
+	// Задача возвращена в pending до закрытия хранилища
+	resp, err = http.Get(ts.URL + "/metrics")
+	if assert.NoError(t, err) {
+		metrics, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Contains(t, string(metrics), `taskhub_task_transitions_total{from="in_progress",to="pending"} 1`)
+	}
+
+	// После остановки хранилище закрыто, фоновая очистка не работает
+	resp, err = http.Get(ts.URL + "/readyz")
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	var r readinessResponse
+	_ = json.Unmarshal(body, &r)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Contains(t, r.Checks, "shutdown")
+	assert.NotEqual(t, "ok", r.Checks["repository"])
+	assert.NotEqual(t, "ok", r.Checks["purger"])
+}
+
+func TestDocsE2E(t *testing.T) {
+	application := newApp(t, config.LoadConfig())
+	ts := httptest.NewServer(application.Engine)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/openapi.json")
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var spec map[string]any
+	assert.NoError(t, json.Unmarshal(body, &spec))
+	assert.Equal(t, "3.0.3", spec["openapi"])
+
+	for path, contentType := range map[string]string{
+		"/docs/":                     "text/html",
+		"/docs/swagger-ui-bundle.js": "javascript",
+	} {
+		resp, err := http.Get(ts.URL + path)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, path)
+		assert.Contains(t, resp.Header.Get("Content-Type"), contentType, path)
+	}
+	resp, err = http.Get(ts.URL + "/docs/missing.js")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,92 @@
+package e2e
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/config"
+)
+
+func TestIdempotencyE2E(t *testing.T) {
+	application := newApp(t, config.LoadConfig())
+	ts := httptest.NewServer(application.Engine)
+	defer ts.Close()
+
+	create := func(path, key, body string) (*http.Response, []byte) {
+		req, _ := http.NewRequest(http.MethodPost, ts.URL+path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if !assert.NoError(t, err) {
+			return &http.Response{}, nil
+		}
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return resp, data
+	}
+	count := func() int {
+		resp, err := http.Get(ts.URL + "/api/v1/tasks")
+		assert.NoError(t, err)
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		var list struct {
+			Tasks []taskResponse `json:"tasks"`
+		}
+		_ = json.Unmarshal(data, &list)
+		return len(list.Tasks)
+	}
+
+	// 1. Первый запрос выполняется, повтор получает тот же 201 без создания новой задачи
+	resp, first := create("/api/v1/tasks", "retry-1", `{"title":"once"}`)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Idempotent-Replayed"))
+	resp, again := create("/api/v1/tasks", "retry-1", `{"title":"once"}`)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "true", resp.Header.Get("Idempotent-Replayed"))
+	assert.Contains(t, resp.Header.Get("Content-Type"), "application/json")
+	assert.Equal(t, first, again)
+	assert.Equal(t, 1, count())
+
+	// 2. Алиас /api — тот же маршрут, ключ общий
+	resp, legacy := create("/api/tasks", "retry-1", `{"title":"once"}`)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, first, legacy)
+
+	// 3. Тот же ключ с другим телом — 422
+	resp, data := create("/api/v1/tasks", "retry-1", `{"title":"other"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	var errResp errorResponse
+	_ = json.Unmarshal(data, &errResp)
+	assert.Equal(t, "TRANSPORT_IDEMPOTENCY_MISMATCH", errResp.Error.Code)
+	assert.Equal(t, 1, count())
+
+	// 4. Ошибка не сохраняется: ключ можно использовать снова, в том числе с исправленным телом
+	resp, _ = create("/api/v1/tasks", "retry-2", `{"title":""}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = create("/api/v1/tasks", "retry-2", `{"title":"fixed"}`)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, 2, count())
+
+	// 5. Без ключа — обычное создание, слишком длинный ключ — 400
+	resp, _ = create("/api/v1/tasks", "", `{"title":"once"}`)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp, _ = create("/api/v1/tasks", strings.Repeat("k", 256), `{"title":"once"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, 3, count())
+
+	// 6. Тело с ключом больше api.idempotency.maxbody не читается в память — 413
+	resp, data = create("/api/v1/tasks", "retry-3", `{"title":"big","description":"`+strings.Repeat("a", 1<<20)+`"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	errResp = errorResponse{}
+	_ = json.Unmarshal(data, &errResp)
+	assert.Equal(t, "TRANSPORT_PAYLOAD_TOO_LARGE", errResp.Error.Code)
+	assert.Contains(t, errResp.Error.Details["body"], "1048576 bytes")
+	assert.Equal(t, 3, count())
+}
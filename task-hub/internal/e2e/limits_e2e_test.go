@@ -0,0 +1,74 @@
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/config"
+)
+
+func TestLimitsE2E_HotReload(t *testing.T) {
+	cfg := config.LoadConfig()
+	application := newApp(t, cfg)
+	w := config.NewWatcher(cfg, nil)
+	application.WatchConfig(w)
+	ts := httptest.NewServer(application.Engine)
+	defer ts.Close()
+
+	get := func(path string) *http.Response {
+		resp, err := http.Get(ts.URL + path)
+		if !assert.NoError(t, err) {
+			return &http.Response{}
+		}
+		resp.Body.Close()
+		return resp
+	}
+	reload := func(change func(c *config.AppConfig)) {
+		next := *cfg
+		change(&next)
+		if assert.NoError(t, w.Apply(&next)) {
+			cfg = &next
+		}
+	}
+
+	// 1. По умолчанию лимита нет
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, get("/api/v1/tasks").StatusCode)
+	}
+
+	// 2. Лимит включается на лету: burst подряд, дальше 429 с Retry-After; health-эндпоинты не ограничены
+	reload(func(c *config.AppConfig) { c.API.RateLimit = config.RateLimitConfig{RPS: 0.01, Burst: 2} })
+	assert.Equal(t, http.StatusOK, get("/api/v1/tasks").StatusCode)
+	assert.Equal(t, http.StatusOK, get("/api/v1/tasks").StatusCode)
+	resp := get("/api/v1/tasks")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, get("/healthz").StatusCode)
+
+	// 3. И выключается
+	reload(func(c *config.AppConfig) { c.API.RateLimit = config.RateLimitConfig{Burst: 2} })
+	assert.Equal(t, http.StatusOK, get("/api/v1/tasks").StatusCode)
+
+	// 4. Дедлайн запросов к задачам меняется на лету: истекший — 504, выгрузка под дедлайн не попадает
+	reload(func(c *config.AppConfig) { c.Task.Timeout = time.Nanosecond })
+	assert.Equal(t, http.StatusGatewayTimeout, get("/api/v1/tasks").StatusCode)
+	resp, err := http.Post(ts.URL+"/api/v1/tasks", "application/json", strings.NewReader(`{"title":"late"}`))
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode)
+	}
+	assert.Equal(t, http.StatusOK, get("/api/v1/tasks/export").StatusCode)
+
+	reload(func(c *config.AppConfig) { c.Task.Timeout = 0 })
+	assert.Equal(t, http.StatusOK, get("/api/v1/tasks").StatusCode)
+
+	// 5. Невалидный лимит отбрасывается, действует прежний
+	next := *cfg
+	next.API.RateLimit = config.RateLimitConfig{RPS: -1}
+	assert.Error(t, w.Apply(&next))
+	assert.Equal(t, http.StatusOK, get("/api/v1/tasks").StatusCode)
+}
@@ -21,6 +21,15 @@ type createTaskRequest struct {
 	Deadline    time.Time `json:"deadline"`
 }
 
+type errorResponse struct {
+	Error struct {
+		Code      string            `json:"code"`
+		Message   string            `json:"message"`
+		Details   map[string]string `json:"details"`
+		RequestID string            `json:"request_id"`
+	} `json:"error"`
+}
+
 type taskResponse struct {
 	ID          string `json:"id"`
 	Title       string `json:"title"`
@@ -32,9 +41,19 @@ type taskResponse struct {
 	Deadline    string `json:"deadline"`
 }
 
+// newApp — приложение для теста; конфиг, с которым оно не собирается, — ошибка теста.
+func newApp(t *testing.T, cfg *config.AppConfig) *app.App {
+	t.Helper()
+	application, err := app.NewApp(cfg)
+	if err != nil {
+		t.Fatalf("app.NewApp: %v", err)
+	}
+	return application
+}
+
 func TestTaskE2E(t *testing.T) {
 	cfg := config.LoadConfig()
-	application := app.NewApp(cfg)
+	application := newApp(t, cfg)
 	ts := httptest.NewServer(application.Engine)
 	defer ts.Close()
 
@@ -46,7 +65,7 @@ func TestTaskE2E(t *testing.T) {
 		Deadline:    time.Now().Add(24 * time.Hour),
 	}
 	body, _ := json.Marshal(createReq)
-	resp, err := http.Post(ts.URL+"/api/tasks", "application/json", bytes.NewReader(body))
+	resp, err := http.Post(ts.URL+"/api/v1/tasks", "application/json", bytes.NewReader(body))
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusCreated, resp.StatusCode)
 	respBody, _ := io.ReadAll(resp.Body)
@@ -57,7 +76,7 @@ func TestTaskE2E(t *testing.T) {
 	assert.NotEmpty(t, created.ID)
 
 	// 2. Получить задачу по id
-	resp, err = http.Get(ts.URL + "/api/tasks/" + created.ID)
+	resp, err = http.Get(ts.URL + "/api/v1/tasks/" + created.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 	respBody, _ = io.ReadAll(resp.Body)
@@ -67,13 +86,99 @@ func TestTaskE2E(t *testing.T) {
 	assert.Equal(t, created.ID, got.ID)
 
 	// 3. Удалить задачу
-	req, _ := http.NewRequest(http.MethodDelete, ts.URL+"/api/tasks/"+created.ID, nil)
+	req, _ := http.NewRequest(http.MethodDelete, ts.URL+"/api/v1/tasks/"+created.ID, nil)
 	resp, err = http.DefaultClient.Do(req)
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
 
 	// 4. Проверить, что задача удалена
-	resp, err = http.Get(ts.URL + "/api/tasks/" + created.ID)
+	resp, err = http.Get(ts.URL + "/api/v1/tasks/" + created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// 5. Восстановить задачу из корзины
+	resp, err = http.Post(ts.URL+"/api/v1/tasks/"+created.ID+"/restore", "application/json", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp, err = http.Get(ts.URL + "/api/v1/tasks/" + created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// 6. Метрики Prometheus
+	resp, err = http.Get(ts.URL + "/metrics")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	respBody, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	metrics := string(respBody)
+	assert.Contains(t, metrics, `taskhub_http_requests_total{method="POST",route="/api/v1/tasks",status="201"} 1`)
+	assert.Contains(t, metrics, `taskhub_task_transitions_total{from="deleted",to="pending"} 1`)
+	assert.Contains(t, metrics, `taskhub_tasks{priority="high",status="pending"} 1`)
+}
+
+func TestTaskE2E_Errors(t *testing.T) {
+	cfg := config.LoadConfig()
+	application := newApp(t, cfg)
+	ts := httptest.NewServer(application.Engine)
+	defer ts.Close()
+
+	decodeError := func(resp *http.Response) errorResponse {
+		var e errorResponse
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		_ = json.Unmarshal(respBody, &e)
+		return e
+	}
+
+	// 1. Некорректный id — 400
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/api/v1/tasks/not-a-uuid", nil)
+	req.Header.Set("X-Request-ID", "req-42")
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	e := decodeError(resp)
+	assert.Equal(t, "TRANSPORT_BAD_REQUEST", e.Error.Code)
+	assert.Equal(t, "req-42", e.Error.RequestID)
+	assert.Equal(t, "req-42", resp.Header.Get("X-Request-ID"))
+
+	// 2. Несуществующая задача — 404 и для чтения, и для смены статуса; без X-Request-ID он генерируется
+	resp, err = http.Get(ts.URL + "/api/v1/tasks/00000000-0000-0000-0000-000000000000")
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	generatedID := resp.Header.Get("X-Request-ID")
+	assert.NotEmpty(t, generatedID)
+	e = decodeError(resp)
+	assert.Equal(t, "REPO_NOT_FOUND", e.Error.Code)
+	assert.Equal(t, generatedID, e.Error.RequestID)
+
+	req, _ = http.NewRequest(http.MethodPatch, ts.URL+"/api/v1/tasks/00000000-0000-0000-0000-000000000000/status",
+		bytes.NewReader([]byte(`{"status":"completed"}`)))
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	// 3. Дедлайн в прошлом — 422
+	body, _ := json.Marshal(createTaskRequest{Title: "Past", Deadline: time.Now().Add(-time.Hour)})
+	resp, err = http.Post(ts.URL+"/api/v1/tasks", "application/json", bytes.NewReader(body))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	e = decodeError(resp)
+	assert.Equal(t, "SERVICE_VALIDATION", e.Error.Code)
+	assert.Contains(t, e.Error.Details["deadline"], "deadline must be in the future")
+
+	// 4. Недопустимый переход статуса — 409
+	body, _ = json.Marshal(createTaskRequest{Title: "Conflict", Deadline: time.Now().Add(time.Hour)})
+	resp, err = http.Post(ts.URL+"/api/v1/tasks", "application/json", bytes.NewReader(body))
+	assert.NoError(t, err)
+	respBody, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	var created taskResponse
+	_ = json.Unmarshal(respBody, &created)
+	req, _ = http.NewRequest(http.MethodPatch, ts.URL+"/api/v1/tasks/"+created.ID+"/status",
+		bytes.NewReader([]byte(`{"status":"completed"}`)))
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, "SERVICE_CONFLICT", decodeError(resp).Error.Code)
 }
@@ -0,0 +1,104 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/config"
+)
+
+func TestTenancyE2E(t *testing.T) {
+	cfg := config.LoadConfig()
+	cfg.Auth.Enabled = true
+	cfg.Auth.APIKeys = []config.APIKeyConfig{
+		{Name: "team-a-bot", Hash: auth.HashKey("a-key"), Scopes: []string{"admin"}, Workspace: "team-a"},
+		{Name: "ops", Hash: auth.HashKey("ops-key"), Scopes: []string{"admin"}},
+		{Name: "dev", Hash: auth.HashKey("dev-key"), Scopes: []string{"tasks:read", "tasks:write"}},
+	}
+	cfg.Auth.JWT = config.JWTConfig{Enabled: true, Algorithm: "HS256", Secret: "e2e-secret", RolesClaim: "roles"}
+	cfg.Tenancy.Quotas = map[string]int{"team-a": 1}
+	application := newApp(t, cfg)
+	ts := httptest.NewServer(application.Engine)
+	defer ts.Close()
+
+	// Токен SSO без claim workspace — обычный случай
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   "alice",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"member"},
+	}).SignedString([]byte("e2e-secret"))
+
+	do := func(method, path, key, workspace string, body []byte) (*http.Response, []byte) {
+		req, _ := http.NewRequest(method, ts.URL+path, bytes.NewReader(body))
+		if key == token {
+			req.Header.Set("Authorization", "Bearer "+token)
+		} else {
+			req.Header.Set("X-API-Key", key)
+		}
+		if workspace != "" {
+			req.Header.Set("X-Workspace-ID", workspace)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return resp, respBody
+	}
+
+	// 1. Ключ team-a создает задачу в своем пространстве
+	resp, body := do(http.MethodPost, "/api/v1/tasks", "a-key", "", []byte(`{"title":"team a task"}`))
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created taskResponse
+	_ = json.Unmarshal(body, &created)
+
+	// 2. Квота team-a исчерпана: 403 со своим кодом, а не 429 ограничителя частоты
+	resp, body = do(http.MethodPost, "/api/v1/tasks", "a-key", "", []byte(`{"title":"one more"}`))
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Contains(t, string(body), "SERVICE_QUOTA_EXCEEDED")
+
+	// 3. Ключ, привязанный к team-a, не может ходить в чужое пространство
+	resp, _ = do(http.MethodGet, "/api/v1/tasks", "a-key", "team-b", nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// 4. Непривязанный ключ видит только пространство из заголовка
+	resp, _ = do(http.MethodGet, "/api/v1/tasks/"+created.ID, "ops-key", "team-b", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp, _ = do(http.MethodGet, "/api/v1/tasks/"+created.ID, "ops-key", "team-a", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, body = do(http.MethodGet, "/api/v1/tasks", "ops-key", "team-b", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var list struct {
+		Tasks []taskResponse `json:"tasks"`
+	}
+	_ = json.Unmarshal(body, &list)
+	assert.Empty(t, list.Tasks)
+
+	// 5. Не-admin без привязки к пространству (ключ member, токен без claim workspace) чужое пространство
+	// выбрать не может — ни прочитать, ни записать; без заголовка работает в пространстве по умолчанию
+	for name, key := range map[string]string{"member key": "dev-key", "jwt": token} {
+		resp, _ = do(http.MethodGet, "/api/v1/tasks/"+created.ID, key, "team-a", nil)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, name)
+		resp, _ = do(http.MethodGet, "/api/v1/tasks", key, "team-a", nil)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, name)
+		resp, _ = do(http.MethodPost, "/api/v1/tasks", key, "team-b", []byte(`{"title":"intruder"}`))
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, name)
+
+		resp, _ = do(http.MethodGet, "/api/v1/tasks/"+created.ID, key, "", nil)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, name)
+		resp, _ = do(http.MethodPost, "/api/v1/tasks", key, "", []byte(`{"title":"own"}`))
+		assert.Equal(t, http.StatusCreated, resp.StatusCode, name)
+	}
+	resp, body = do(http.MethodGet, "/api/v1/tasks", "ops-key", "team-b", nil)
+	list.Tasks = nil
+	_ = json.Unmarshal(body, &list)
+	assert.Empty(t, list.Tasks)
+}
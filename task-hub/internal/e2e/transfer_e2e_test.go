@@ -0,0 +1,162 @@
+package e2e
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/config"
+)
+
+type importResponse struct {
+	Imported int  `json:"imported"`
+	Failed   int  `json:"failed"`
+	Aborted  bool `json:"aborted"`
+	Errors   []struct {
+		Row    int    `json:"row"`
+		ID     string `json:"id"`
+		Status int    `json:"status"`
+		Error  struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	} `json:"errors"`
+}
+
+func TestTransferE2E(t *testing.T) {
+	cfg := config.LoadConfig()
+	cfg.Auth.Enabled = true
+	cfg.Auth.APIKeys = []config.APIKeyConfig{
+		{Name: "ops", Hash: auth.HashKey("ops-key"), Scopes: []string{"admin"}},
+		{Name: "dev", Hash: auth.HashKey("dev-key"), Scopes: []string{"tasks:read", "tasks:write"}},
+	}
+	application := newApp(t, cfg)
+	ts := httptest.NewServer(application.Engine)
+	defer ts.Close()
+
+	do := func(method, path, key, workspace, contentType, body string) (*http.Response, string) {
+		req, _ := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		req.Header.Set("X-API-Key", key)
+		req.Header.Set("X-Workspace-ID", workspace)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if !assert.NoError(t, err) {
+			return &http.Response{}, ""
+		}
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return resp, string(data)
+	}
+	create := func(title string) string {
+		_, body := do(http.MethodPost, "/api/v1/tasks", "ops-key", "team-a", "application/json", `{"title":"`+title+`","description":"a, \"quoted\"\nline"}`)
+		var task taskResponse
+		_ = json.Unmarshal([]byte(body), &task)
+		return task.ID
+	}
+
+	// Задачи в разных состояниях: в работе, завершенная после паузы, в корзине
+	a, b, c := create("a"), create("b"), create("c")
+	for _, step := range []struct{ id, status string }{
+		{a, "in_progress"}, {b, "in_progress"}, {b, "paused"}, {b, "in_progress"}, {b, "completed"},
+	} {
+		resp, _ := do(http.MethodPatch, "/api/v1/tasks/"+step.id+"/status", "ops-key", "team-a", "application/json", `{"status":"`+step.status+`"}`)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+	resp, _ := do(http.MethodDelete, "/api/v1/tasks/"+c, "ops-key", "team-a", "", "")
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// 1. Выгрузка: корзина только по запросу, фильтр по статусу, формат и имя файла
+	resp, body := do(http.MethodGet, "/api/v1/tasks/export", "ops-key", "team-a", "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var records []map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(body), &records))
+	assert.Len(t, records, 2)
+	resp, body = do(http.MethodGet, "/api/v1/tasks/export?format=ndjson&status=deleted", "ops-key", "team-a", "", "")
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "tasks.ndjson")
+	assert.Equal(t, 1, strings.Count(body, "\n"))
+	assert.Contains(t, body, `"previous_status":"pending"`)
+	resp, _ = do(http.MethodGet, "/api/v1/tasks/export?format=xml", "ops-key", "team-a", "", "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = do(http.MethodGet, "/api/v1/tasks/export?priority=urgent", "ops-key", "team-a", "", "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// 2. Каждый формат: загрузка в новое пространство и повторная выгрузка дают тот же файл
+	for i, format := range []string{"json", "ndjson", "csv"} {
+		workspace := "copy-" + format
+		_, exported := do(http.MethodGet, "/api/v1/tasks/export?include_deleted=true&format="+format, "ops-key", "team-a", "", "")
+		resp, body = do(http.MethodPost, "/api/v1/tasks/import?format="+format, "ops-key", workspace, "", exported)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, format)
+		var result importResponse
+		_ = json.Unmarshal([]byte(body), &result)
+		assert.Equal(t, 3, result.Imported, format)
+
+		_, again := do(http.MethodGet, "/api/v1/tasks/export?include_deleted=true&format="+format, "ops-key", workspace, "", "")
+		assert.Equal(t, exported, again, format)
+
+		// Задача из корзины восстанавливается, в работе — продолжается
+		resp, _ = do(http.MethodPost, "/api/v1/tasks/"+c+"/restore", "ops-key", workspace, "", "")
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, format)
+		resp, _ = do(http.MethodPatch, "/api/v1/tasks/"+a+"/status", "ops-key", workspace, "application/json", `{"status":"completed"}`)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, format)
+
+		// Повторная загрузка — конфликт по каждой строке; формат по Content-Type
+		contentType := []string{"application/json", "application/x-ndjson", "text/csv"}[i]
+		resp, body = do(http.MethodPost, "/api/v1/tasks/import", "ops-key", workspace, contentType, exported)
+		assert.Equal(t, http.StatusMultiStatus, resp.StatusCode, format)
+		result = importResponse{}
+		_ = json.Unmarshal([]byte(body), &result)
+		assert.Equal(t, 3, result.Failed, format)
+		if assert.Len(t, result.Errors, 3, format) {
+			assert.Equal(t, http.StatusConflict, result.Errors[0].Status)
+			assert.NotEmpty(t, result.Errors[0].ID)
+		}
+	}
+
+	// 3. Ошибки по строкам: разбор, валидация моделью, остальные строки загружаются
+	ndjson := strings.Join([]string{
+		`{"id":"11111111-1111-1111-1111-111111111111","title":"ok","status":"pending","created_at":"2025-01-01T10:00:00Z"}`,
+		`not json`,
+		``,
+		`{"id":"22222222-2222-2222-2222-222222222222","title":"","status":"pending","created_at":"2025-01-01T10:00:00Z"}`,
+		`{"id":"33333333-3333-3333-3333-333333333333","title":"x","status":"completed","created_at":"2025-01-01T10:00:00Z"}`,
+	}, "\n")
+	resp, body = do(http.MethodPost, "/api/v1/tasks/import?format=ndjson", "ops-key", "rows", "", ndjson)
+	assert.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+	var result importResponse
+	_ = json.Unmarshal([]byte(body), &result)
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, 3, result.Failed)
+	assert.False(t, result.Aborted)
+	if assert.Len(t, result.Errors, 3) {
+		assert.Equal(t, 2, result.Errors[0].Row)
+		assert.Equal(t, http.StatusBadRequest, result.Errors[0].Status)
+		assert.Equal(t, 4, result.Errors[1].Row)
+		assert.Equal(t, "SERVICE_VALIDATION", result.Errors[1].Error.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, result.Errors[2].Status)
+	}
+
+	// 4. Битый JSON обрывает загрузку, загруженное до ошибки остается
+	resp, body = do(http.MethodPost, "/api/v1/tasks/import", "ops-key", "broken", "application/json",
+		`[{"id":"44444444-4444-4444-4444-444444444444","title":"ok","status":"pending","created_at":"2025-01-01T10:00:00Z"}, {"id": oops`)
+	assert.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+	result = importResponse{}
+	_ = json.Unmarshal([]byte(body), &result)
+	assert.Equal(t, 1, result.Imported)
+	assert.True(t, result.Aborted)
+
+	// 5. Не документ формата, неизвестная колонка csv — 400; импорт только для admin
+	resp, _ = do(http.MethodPost, "/api/v1/tasks/import", "ops-key", "bad", "application/json", `{"id":"x"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = do(http.MethodPost, "/api/v1/tasks/import?format=csv", "ops-key", "bad", "", "id,title,status,created_at,color\n")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = do(http.MethodPost, "/api/v1/tasks/import?format=ndjson", "dev-key", "dev", "", `{"id":"55555555-5555-5555-5555-555555555555","title":"x","status":"pending","created_at":"2025-01-01T10:00:00Z"}`)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
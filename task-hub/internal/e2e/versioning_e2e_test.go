@@ -0,0 +1,63 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/config"
+)
+
+func TestLegacyAPIE2E(t *testing.T) {
+	cfg := config.LoadConfig()
+	cfg.API.Legacy.Deprecation = time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	cfg.API.Legacy.Sunset = time.Date(2027, 5, 1, 0, 0, 0, 0, time.UTC)
+	application := newApp(t, cfg)
+	ts := httptest.NewServer(application.Engine)
+	defer ts.Close()
+
+	// 1. Создание через алиас без версии — тот же ответ, что и в v1, плюс заголовки устаревания
+	resp, err := http.Post(ts.URL+"/api/tasks", "application/json", bytes.NewReader([]byte(`{"title":"legacy"}`)))
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "@1793491200", resp.Header.Get("Deprecation"))
+	assert.Equal(t, "Sat, 01 May 2027 00:00:00 GMT", resp.Header.Get("Sunset"))
+	assert.Equal(t, `</api/v1/tasks>; rel="successor-version"`, resp.Header.Get("Link"))
+	var created taskResponse
+	_ = json.Unmarshal(body, &created)
+
+	// 2. Задача видна через v1, и у v1 заголовков устаревания нет
+	resp, err = http.Get(ts.URL + "/api/v1/tasks/" + created.ID)
+	assert.NoError(t, err)
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Deprecation"))
+	assert.Empty(t, resp.Header.Get("Sunset"))
+	var got taskResponse
+	_ = json.Unmarshal(body, &got)
+	assert.Equal(t, created, got)
+
+	// 3. Ошибки алиаса тоже помечены
+	resp, err = http.Get(ts.URL + "/api/tasks/not-a-uuid")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, `</api/v1/tasks/not-a-uuid>; rel="successor-version"`, resp.Header.Get("Link"))
+
+	// 4. Алиас можно отключить
+	cfg.API.Legacy.Enabled = false
+	ts2 := httptest.NewServer(newApp(t, cfg).Engine)
+	defer ts2.Close()
+	resp, err = http.Get(ts2.URL + "/api/tasks")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
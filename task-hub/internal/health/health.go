@@ -0,0 +1,72 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrShuttingDown — приложение завершается и больше не принимает трафик.
+var ErrShuttingDown = errors.New("shutting down")
+
+// Check — проверка одной зависимости, nil — все в порядке.
+type Check func(ctx context.Context) error
+
+// Checker — набор проверок готовности приложения.
+// -- Живость (/healthz) не зависит от проверок: если процесс отвечает, он жив.
+// -- Готовность (/readyz) — все проверки прошли и приложение не в процессе остановки.
+type Checker struct {
+	mu           sync.RWMutex
+	names        []string
+	checks       map[string]Check
+	shuttingDown atomic.Bool
+}
+
+// Конструктор.
+func New() *Checker {
+	return &Checker{checks: make(map[string]Check)}
+}
+
+// Add — регистрирует проверку готовности под именем (повторное имя заменяет проверку).
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.checks[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.checks[name] = check
+}
+
+// SetShuttingDown — помечает приложение как завершающееся: готовность сразу становится ложной.
+func (c *Checker) SetShuttingDown() {
+	c.shuttingDown.Store(true)
+}
+
+// ShuttingDown — идет ли остановка приложения.
+func (c *Checker) ShuttingDown() bool {
+	return c.shuttingDown.Load()
+}
+
+// Ready — выполняет все проверки.
+// -- Возвращает результат по каждой проверке ("ok" или текст ошибки) и общий итог.
+func (c *Checker) Ready(ctx context.Context) (map[string]string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	results := make(map[string]string, len(c.names)+1)
+	ready := true
+	if c.ShuttingDown() {
+		results["shutdown"] = ErrShuttingDown.Error()
+		ready = false
+	}
+	for _, name := range c.names {
+		if err := c.checks[name](ctx); err != nil {
+			results[name] = err.Error()
+			ready = false
+			continue
+		}
+		results[name] = "ok"
+	}
+	return results, ready
+}
@@ -0,0 +1,33 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Ready(t *testing.T) {
+	c := New()
+	c.Add("repository", func(context.Context) error { return nil })
+
+	results, ready := c.Ready(context.Background())
+	assert.True(t, ready)
+	assert.Equal(t, map[string]string{"repository": "ok"}, results)
+
+	c.Add("workers", func(context.Context) error { return errors.New("purger stopped") })
+	results, ready = c.Ready(context.Background())
+	assert.False(t, ready)
+	assert.Equal(t, "purger stopped", results["workers"])
+}
+
+func TestChecker_ShuttingDown(t *testing.T) {
+	c := New()
+	c.Add("repository", func(context.Context) error { return nil })
+	c.SetShuttingDown()
+
+	results, ready := c.Ready(context.Background())
+	assert.False(t, ready)
+	assert.Equal(t, ErrShuttingDown.Error(), results["shutdown"])
+}
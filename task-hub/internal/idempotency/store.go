@@ -0,0 +1,120 @@
+// Package idempotency — хранилище ответов по ключам идемпотентности (заголовок Idempotency-Key):
+// повтор запроса с тем же ключом получает сохраненный ответ вместо повторного выполнения.
+package idempotency
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrMismatch — ключ уже использован с другим телом запроса.
+	ErrMismatch = errors.New("idempotency key is already used with a different request")
+	// ErrInProgress — запрос с этим ключом еще выполняется.
+	ErrInProgress = errors.New("request with this idempotency key is in progress")
+)
+
+// sweepInterval — как часто из хранилища вычищаются истекшие ключи.
+const sweepInterval = time.Minute
+
+// Response — сохраненный ответ для повтора.
+type Response struct {
+	Status      int
+	ContentType string
+	Body        []byte
+}
+
+// entry — ключ: отпечаток запроса и ответ (nil, пока запрос выполняется).
+type entry struct {
+	fingerprint string
+	response    *Response
+	expires     time.Time
+}
+
+// Store — ключи в памяти процесса с TTL.
+type Store struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]*entry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewStore — конструктор; ttl — сколько хранится ответ (и резерв ключа на время выполнения запроса).
+func NewStore(ttl time.Duration) *Store {
+	return &Store{ttl: ttl, entries: make(map[string]*entry), now: time.Now}
+}
+
+// SetTTL — смена TTL на лету (горячая перезагрузка конфига); действует для новых ключей.
+func (s *Store) SetTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ttl = ttl
+}
+
+// Begin — начало запроса с ключом key и отпечатком тела fingerprint.
+// -- 1. Ключ новый или истек — резервируется, возвращается (nil, nil): запрос нужно выполнить
+// --    и затем вызвать Complete (сохранить ответ) или Release (освободить ключ).
+// -- 2. Ответ сохранен и отпечаток совпадает — сохраненный ответ для повтора.
+// -- 3. Отпечаток другой — ErrMismatch; тот же запрос еще выполняется — ErrInProgress.
+func (s *Store) Begin(key, fingerprint string) (*Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.sweep(now)
+
+	e, ok := s.entries[key]
+	if !ok || !now.Before(e.expires) {
+		s.entries[key] = &entry{fingerprint: fingerprint, expires: now.Add(s.ttl)}
+		return nil, nil
+	}
+	switch {
+	case e.fingerprint != fingerprint:
+		return nil, ErrMismatch
+	case e.response == nil:
+		return nil, ErrInProgress
+	}
+	return e.response, nil
+}
+
+// Complete — сохранение ответа по зарезервированному ключу; TTL отсчитывается от этого момента.
+func (s *Store) Complete(key string, resp Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok {
+		e.response = &resp
+		e.expires = s.now().Add(s.ttl)
+	}
+}
+
+// Release — освобождение ключа без сохранения ответа (запрос не удался, его можно повторить с тем же ключом).
+func (s *Store) Release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok && e.response == nil {
+		delete(s.entries, key)
+	}
+}
+
+// Len — количество неистекших ключей.
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastSweep = time.Time{}
+	s.sweep(s.now())
+	return len(s.entries)
+}
+
+// sweep — удаление истекших ключей не чаще раза в sweepInterval; вызывается под блокировкой.
+func (s *Store) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, e := range s.entries {
+		if !now.Before(e.expires) {
+			delete(s.entries, key)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package idempotency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_Replay(t *testing.T) {
+	s := NewStore(time.Hour)
+
+	resp, err := s.Begin("k", "a")
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+
+	// Пока запрос выполняется — повтор получает ErrInProgress, другое тело — ErrMismatch
+	_, err = s.Begin("k", "a")
+	assert.ErrorIs(t, err, ErrInProgress)
+	_, err = s.Begin("k", "b")
+	assert.ErrorIs(t, err, ErrMismatch)
+
+	s.Complete("k", Response{Status: 201, ContentType: "application/json", Body: []byte(`{"id":"1"}`)})
+	resp, err = s.Begin("k", "a")
+	assert.NoError(t, err)
+	if !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Equal(t, 201, resp.Status)
+	assert.Equal(t, `{"id":"1"}`, string(resp.Body))
+	_, err = s.Begin("k", "b")
+	assert.ErrorIs(t, err, ErrMismatch)
+}
+
+func TestStore_Release(t *testing.T) {
+	s := NewStore(time.Hour)
+	_, _ = s.Begin("k", "a")
+	s.Release("k")
+
+	// Ключ свободен и для другого тела
+	resp, err := s.Begin("k", "b")
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+
+	// Сохраненный ответ Release не удаляет
+	s.Complete("k", Response{Status: 201})
+	s.Release("k")
+	resp, err = s.Begin("k", "b")
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+}
+
+func TestStore_TTL(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewStore(time.Hour)
+	s.now = func() time.Time { return now }
+
+	_, _ = s.Begin("k", "a")
+	s.Complete("k", Response{Status: 201})
+	_, _ = s.Begin("other", "x")
+	assert.Equal(t, 2, s.Len())
+
+	// После TTL ключ снова новый, в том числе для другого тела; зависший резерв тоже истекает
+	now = now.Add(time.Hour)
+	assert.Equal(t, 0, s.Len())
+	resp, err := s.Begin("k", "b")
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+
+	// Новый TTL действует для новых ключей
+	s.SetTTL(time.Minute)
+	s.Complete("k", Response{Status: 201})
+	now = now.Add(time.Minute)
+	assert.Equal(t, 0, s.Len())
+}
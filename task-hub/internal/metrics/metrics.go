@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/ports"
+)
+
+const namespace = "taskhub"
+
+// Убеждаемся, что Metrics реализует интерфейс TaskMetrics.
+var _ ports.TaskMetrics = (*Metrics)(nil)
+
+// Metrics — метрики приложения в формате Prometheus.
+// -- HTTP: счетчик и гистограмма задержек по методу и маршруту.
+// -- Задачи: количество по статусу и приоритету, счетчик переходов, длительность выполнения при завершении.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	tasks        *prometheus.GaugeVec
+	transitions  *prometheus.CounterVec
+	taskDuration prometheus.Histogram
+
+	mu    sync.Mutex
+	state map[taskKey]taskState // последнее известное состояние задач
+}
+
+// taskKey — задача в state: id уникален только внутри рабочего пространства (импорт переносит id как есть).
+type taskKey struct {
+	workspace string
+	id        uuid.UUID
+}
+
+type taskState struct {
+	status   models.TaskStatus
+	priority models.TaskPriority
+}
+
+// New — конструктор с собственным реестром (плюс стандартные метрики процесса и Go runtime).
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Количество HTTP-запросов по методу, маршруту и статусу.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Длительность обработки HTTP-запросов.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		tasks: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "tasks",
+			Help:      "Количество задач по статусу и приоритету.",
+		}, []string{"status", "priority"}),
+		transitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "task_transitions_total",
+			Help:      "Количество переходов задач между статусами.",
+		}, []string{"from", "to"}),
+		taskDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "task_duration_seconds",
+			Help:      "Фактическое время работы над задачей (без пауз) на момент завершения.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 10), // от секунды до ~3 дней
+		}),
+		state: make(map[taskKey]taskState),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration, m.tasks, m.transitions, m.taskDuration,
+	)
+	return m
+}
+
+// Handler — HTTP-обработчик /metrics.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Registry — реестр метрик (для тестов и регистрации дополнительных коллекторов).
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// ObserveHTTP — учет обработанного HTTP-запроса.
+func (m *Metrics) ObserveHTTP(method, route string, status int, elapsed time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(elapsed.Seconds())
+}
+
+// TaskSaved — обновление метрик по новому состоянию задачи.
+// -- 1. Новая задача — +1 в gauge ее статуса/приоритета.
+// -- 2. Изменение статуса или приоритета — перенос между gauge; смена статуса — переход.
+// -- 3. Переход в completed — наблюдение длительности.
+func (m *Metrics) TaskSaved(task *models.Task) {
+	key := taskKey{workspace: task.Workspace(), id: task.ID()}
+	next := taskState{status: task.Status(), priority: task.Priority()}
+
+	m.mu.Lock()
+	prev, known := m.state[key]
+	m.state[key] = next
+	m.mu.Unlock()
+
+	if known && prev == next {
+		return
+	}
+	if known {
+		m.tasks.WithLabelValues(string(prev.status), string(prev.priority)).Dec()
+	}
+	m.tasks.WithLabelValues(string(next.status), string(next.priority)).Inc()
+
+	if !known || prev.status == next.status {
+		return
+	}
+	m.transitions.WithLabelValues(string(prev.status), string(next.status)).Inc()
+	if next.status == models.TaskStatusCompleted {
+		m.taskDuration.Observe(task.Duration().Seconds())
+	}
+}
+
+// TaskRemoved — задача удалена из хранилища.
+func (m *Metrics) TaskRemoved(workspace string, id uuid.UUID) {
+	key := taskKey{workspace: workspace, id: id}
+
+	m.mu.Lock()
+	prev, known := m.state[key]
+	delete(m.state, key)
+	m.mu.Unlock()
+
+	if known {
+		m.tasks.WithLabelValues(string(prev.status), string(prev.priority)).Dec()
+	}
+}
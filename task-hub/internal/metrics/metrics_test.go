@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+)
+
+func TestMetrics_TaskLifecycle(t *testing.T) {
+	m := New()
+	task, err := models.NewTask("metrics", "", models.TaskPriorityHigh)
+	assert.NoError(t, err)
+
+	m.TaskSaved(task)
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.tasks.WithLabelValues("pending", "high")))
+
+	assert.NoError(t, task.Start())
+	m.TaskSaved(task)
+	m.TaskSaved(task) // повторное сохранение без изменений не считается переходом
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.tasks.WithLabelValues("pending", "high")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.tasks.WithLabelValues("in_progress", "high")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.transitions.WithLabelValues("pending", "in_progress")))
+
+	assert.NoError(t, task.Complete())
+	m.TaskSaved(task)
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.transitions.WithLabelValues("in_progress", "completed")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.taskDuration))
+
+	m.TaskRemoved(task.Workspace(), task.ID())
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.tasks.WithLabelValues("completed", "high")))
+}
+
+func TestMetrics_SameIDInTwoWorkspaces(t *testing.T) {
+	m := New()
+	source, err := models.NewTask("imported", "", models.TaskPriorityLow)
+	if !assert.NoError(t, err) {
+		return
+	}
+	// Одна и та же выгрузка импортирована в два пространства: id совпадают, задачи разные
+	var copies []*models.Task
+	for _, workspace := range []string{"team-a", "team-b"} {
+		task, err := models.TaskFromSnapshot(source.Snapshot())
+		if !assert.NoError(t, err) || !assert.NoError(t, task.SetWorkspace(workspace)) {
+			return
+		}
+		m.TaskSaved(task)
+		copies = append(copies, task)
+	}
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.tasks.WithLabelValues("pending", "low")))
+
+	assert.NoError(t, copies[1].Start())
+	m.TaskSaved(copies[1])
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.tasks.WithLabelValues("pending", "low")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.tasks.WithLabelValues("in_progress", "low")))
+
+	m.TaskRemoved("team-a", source.ID())
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.tasks.WithLabelValues("pending", "low")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.tasks.WithLabelValues("in_progress", "low")))
+}
+
+func TestMetrics_ObserveHTTP(t *testing.T) {
+	m := New()
+	m.ObserveHTTP("GET", "/api/tasks/:id", 200, 5*time.Millisecond)
+	m.ObserveHTTP("GET", "/api/tasks/:id", 200, 7*time.Millisecond)
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "/api/tasks/:id", "200")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.httpDuration))
+}
@@ -0,0 +1,285 @@
+// Package openapi — генерация документа OpenAPI 3 по аннотациям хендлеров (@@route, @@desc, ...) и DTO.
+//
+// Аннотации пишутся в doc-комментарии хендлера:
+//
+//	// @@route   PATCH /api/tasks/:id/status
+//	// @@desc    Изменить статус задачи
+//	// @@accept  json [Type]                — тело запроса: Type или тип переменной, в которую делается ShouldBindJSON
+//	// @@query   include_deleted boolean Описание — query-параметр
+//	// @@header  Idempotency-Key string Описание — параметр-заголовок
+//	// @@success 200 TaskResponse            — тип ответа из пакета, []тип, {"key": type} или ничего
+//	// @@error   404 not found               — ответ ErrorResponse; если первое слово — тип из пакета, то он
+//
+// Генератор запускается через go generate (см. internal/transport/http/openapi.go).
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Значения Options по умолчанию: их использует и go generate, и тест на расхождение спецификации с роутером.
+const (
+	DefaultTitle        = "Task Hub API"
+	DefaultVersion      = "1.0.0"
+	DefaultSecurePrefix = "/api"
+)
+
+// Options — параметры генерации.
+type Options struct {
+	Dir          string // пакет с хендлерами и DTO
+	Title        string
+	Version      string
+	SecurePrefix string // маршруты с этим префиксом требуют аутентификации и принимают X-Workspace-ID (по умолчанию /api)
+}
+
+// Document — документ OpenAPI 3 (только используемое подмножество).
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem — операции пути по HTTP-методу в нижнем регистре.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema — JSON Schema в варианте OpenAPI 3.0.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Generate — собирает документ по пакету opts.Dir и возвращает его в виде JSON с отступами.
+func Generate(opts Options) ([]byte, error) {
+	doc, err := Build(opts)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Build — собирает документ по пакету opts.Dir.
+func Build(opts Options) (*Document, error) {
+	if opts.Title == "" {
+		opts.Title = DefaultTitle
+	}
+	if opts.Version == "" {
+		opts.Version = DefaultVersion
+	}
+	if opts.SecurePrefix == "" {
+		opts.SecurePrefix = DefaultSecurePrefix
+	}
+	pkg, err := parsePackage(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := pkg.routes()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		OpenAPI:    "3.0.3",
+		Info:       Info{Title: opts.Title, Version: opts.Version},
+		Paths:      map[string]PathItem{},
+		Components: Components{Schemas: map[string]*Schema{}},
+	}
+	b := &schemaBuilder{pkg: pkg, schemas: doc.Components.Schemas}
+	secured := false
+	for _, r := range routes {
+		op, err := pkg.operation(r, b)
+		if err != nil {
+			return nil, fmt.Errorf("%s (%s %s): %w", r.handler, r.method, r.path, err)
+		}
+		if strings.HasPrefix(r.path, opts.SecurePrefix) {
+			secure(op)
+			secured = true
+		}
+		op.Tags = []string{tag(r.path, opts.SecurePrefix)}
+
+		path := openAPIPath(r.path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = PathItem{}
+			doc.Paths[path] = item
+		}
+		method := strings.ToLower(r.method)
+		if _, dup := item[method]; dup {
+			return nil, fmt.Errorf("%s %s: route is annotated twice", r.method, r.path)
+		}
+		item[method] = op
+	}
+	if secured {
+		// На ErrorResponse ссылаются ответы 401/403 auth-middleware, даже если хендлеры его не упоминают.
+		if _, err := b.named(errorSchema, false); err != nil {
+			return nil, err
+		}
+		doc.Components.SecuritySchemes = map[string]SecurityScheme{
+			"ApiKeyAuth": {Type: "apiKey", In: "header", Name: "X-API-Key"},
+			"BearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+		}
+	}
+	return doc, nil
+}
+
+// Operations — список "METHOD /path" в формате путей gin (/api/tasks/:id), для сверки с роутером.
+func (d *Document) Operations() []string {
+	var ops []string
+	for path, item := range d.Paths {
+		for method := range item {
+			ops = append(ops, strings.ToUpper(method)+" "+ginPath(path))
+		}
+	}
+	return ops
+}
+
+// secure — схемы аутентификации, заголовок рабочего пространства и ответы auth-middleware.
+func secure(op *Operation) {
+	op.Security = []map[string][]string{{"ApiKeyAuth": {}}, {"BearerAuth": {}}}
+	op.Parameters = append(op.Parameters, Parameter{
+		Name:        "X-Workspace-ID",
+		In:          "header",
+		Description: "Рабочее пространство, если ключ или токен к нему не привязан",
+		Schema:      &Schema{Type: "string"},
+	})
+	if _, ok := op.Responses["401"]; !ok {
+		op.Responses["401"] = errorResponse("Нет учетных данных или они неверны")
+	}
+	if _, ok := op.Responses["403"]; !ok {
+		op.Responses["403"] = errorResponse("Недостаточно прав")
+	}
+}
+
+func errorResponse(desc string) *Response {
+	return &Response{Description: desc, Content: jsonContent(&Schema{Ref: ref(errorSchema)})}
+}
+
+func jsonContent(s *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: s}}
+}
+
+// tag — первый сегмент пути после защищенного префикса и версии (/api/v1/tasks → tasks); служебные маршруты — system.
+func tag(path, securePrefix string) string {
+	if !strings.HasPrefix(path, securePrefix+"/") {
+		return "system"
+	}
+	rest := strings.TrimPrefix(path, securePrefix+"/")
+	seg, next, _ := strings.Cut(rest, "/")
+	if isVersion(seg) && next != "" {
+		seg, _, _ = strings.Cut(next, "/")
+	}
+	return seg
+}
+
+// isVersion — сегмент пути вида v1, v2, ...
+func isVersion(seg string) bool {
+	if len(seg) < 2 || seg[0] != 'v' {
+		return false
+	}
+	for _, r := range seg[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// openAPIPath — /api/tasks/:id → /api/tasks/{id}.
+func openAPIPath(path string) string {
+	segs := strings.Split(path, "/")
+	for i, s := range segs {
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			segs[i] = "{" + s[1:] + "}"
+		}
+	}
+	return strings.Join(segs, "/")
+}
+
+// ginPath — обратное преобразование: /api/tasks/{id} → /api/tasks/:id.
+func ginPath(path string) string {
+	segs := strings.Split(path, "/")
+	for i, s := range segs {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			segs[i] = ":" + s[1:len(s)-1]
+		}
+	}
+	return strings.Join(segs, "/")
+}
+
+// pathParams — имена параметров пути в порядке появления.
+func pathParams(path string) []string {
+	var params []string
+	for _, s := range strings.Split(path, "/") {
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			params = append(params, s[1:])
+		}
+	}
+	return params
+}
+
+func ref(name string) string {
+	return "#/components/schemas/" + name
+}
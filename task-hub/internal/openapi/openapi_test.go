@@ -0,0 +1,122 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testHandlers = `package api
+
+import "time"
+
+// ItemRequest — тело создания.
+type ItemRequest struct {
+	Name string ` + "`json:\"name\" binding:\"required\"`" + `
+	Note string ` + "`json:\"note\"`" + `
+}
+
+type ItemResponse struct {
+	ID        string     ` + "`json:\"id\"`" + `
+	DoneAt    *time.Time ` + "`json:\"done_at,omitempty\"`" + `
+	Tags      []string   ` + "`json:\"tags\"`" + `
+}
+
+type ErrorResponse struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+
+// @@route POST /api/items
+// @@desc  Создать
+// @@accept json
+// @@header Idempotency-Key string Ключ повтора
+// @@success 201 ItemResponse
+// @@error 400 bad body
+// @@error 400 bad name
+func Create(c ctx) {
+	var req ItemRequest
+	_ = c.ShouldBindJSON(&req)
+}
+
+// @@route GET /api/v2/items/:id/state
+// @@query verbose boolean Подробно
+// @@success 200 {"state": string, "count": integer}
+func State(c ctx) {}
+
+// @@route POST /api/items/import
+// @@accept json []ItemRequest
+// @@success 200 []ItemResponse
+func Import(c ctx) {}
+
+// @@route GET /healthz
+// @@success 204
+func Health(c ctx) {}
+
+type ctx interface{ ShouldBindJSON(any) error }
+`
+
+func writePackage(t *testing.T, src string) string {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "api.go"), []byte(src), 0o644))
+	return dir
+}
+
+func TestBuild(t *testing.T) {
+	doc, err := Build(Options{Dir: writePackage(t, testHandlers)})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.ElementsMatch(t, []string{"POST /api/items", "GET /api/v2/items/:id/state", "POST /api/items/import", "GET /healthz"}, doc.Operations())
+
+	create := doc.Paths["/api/items"]["post"]
+	assert.Equal(t, ref("ItemRequest"), create.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, "bad body; bad name", create.Responses["400"].Description)
+	assert.Contains(t, create.Responses, "401") // защищенный маршрут
+	assert.Len(t, create.Security, 2)
+	assert.Equal(t, Parameter{Name: "Idempotency-Key", In: "header", Description: "Ключ повтора", Schema: &Schema{Type: "string"}}, create.Parameters[0])
+
+	req := doc.Components.Schemas["ItemRequest"]
+	assert.Equal(t, []string{"name"}, req.Required)
+	assert.Equal(t, "тело создания.", req.Description)
+	resp := doc.Components.Schemas["ItemResponse"]
+	assert.Equal(t, []string{"id", "tags"}, resp.Required)
+	assert.Equal(t, "date-time", resp.Properties["done_at"].Format)
+	assert.Contains(t, doc.Components.Schemas, "ErrorResponse")
+
+	state := doc.Paths["/api/v2/items/{id}/state"]["get"]
+	assert.Equal(t, []string{"items"}, state.Tags) // сегмент версии пропускается
+	assert.Equal(t, "id", state.Parameters[0].Name)
+	assert.Equal(t, "path", state.Parameters[0].In)
+	assert.Equal(t, "verbose", state.Parameters[1].Name)
+	assert.Equal(t, "integer", state.Responses["200"].Content["application/json"].Schema.Properties["count"].Type)
+
+	// Явный тип тела без ShouldBindJSON и массивы
+	imp := doc.Paths["/api/items/import"]["post"]
+	body := imp.RequestBody.Content["application/json"].Schema
+	assert.Equal(t, "array", body.Type)
+	assert.Equal(t, ref("ItemRequest"), body.Items.Ref)
+	assert.Equal(t, ref("ItemResponse"), imp.Responses["200"].Content["application/json"].Schema.Items.Ref)
+
+	health := doc.Paths["/healthz"]["get"]
+	assert.Empty(t, health.Security)
+	assert.Nil(t, health.Responses["204"].Content)
+	assert.Equal(t, []string{"system"}, health.Tags)
+}
+
+func TestBuild_Errors(t *testing.T) {
+	cases := map[string]string{
+		"unknown annotation":  "// @@route GET /x\n// @@success 200\n// @@produce json\nfunc X() {}\n",
+		"missing success":     "// @@route GET /x\nfunc X() {}\n",
+		"unknown type":        "// @@route GET /x\n// @@success 200 Missing\nfunc X() {}\n",
+		"bad status":          "// @@route GET /x\n// @@success 2000\nfunc X() {}\n",
+		"accept without bind": "// @@route POST /x\n// @@accept json\n// @@success 204\nfunc X() {}\n",
+	}
+	for name, src := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Build(Options{Dir: writePackage(t, "package api\n\n"+src)})
+			assert.Error(t, err)
+		})
+	}
+}
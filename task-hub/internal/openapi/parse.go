@@ -0,0 +1,323 @@
+package openapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// errorSchema — тип тела ответа @@error по умолчанию.
+const errorSchema = "ErrorResponse"
+
+// pkgInfo — разобранный пакет с хендлерами.
+type pkgInfo struct {
+	dir   string
+	fset  *token.FileSet
+	files []*ast.File
+	types map[string]typeDecl
+}
+
+// typeDecl — объявление типа верхнего уровня и файл, в котором оно сделано (нужен для импортов).
+type typeDecl struct {
+	spec *ast.TypeSpec
+	doc  *ast.CommentGroup
+	file *ast.File
+}
+
+// route — аннотации одного хендлера.
+type route struct {
+	handler string
+	fn      *ast.FuncDecl
+	file    *ast.File
+
+	method, path, desc string
+	accept             string
+	acceptType         string  // тип тела из @@accept; пусто — тип переменной ShouldBindJSON
+	params             []param // @@query и @@header в порядке объявления
+	success            *reply
+	errors             []reply
+}
+
+type reply struct {
+	code int
+	typ  string // имя типа, {"key": type} или пусто
+	desc string
+}
+
+// param — параметр запроса вне пути: in — query или header.
+type param struct {
+	in, name, typ, desc string
+}
+
+func parsePackage(dir string) (*pkgInfo, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(abs)
+	if err != nil {
+		return nil, err
+	}
+	pkg := &pkgInfo{dir: abs, fset: token.NewFileSet(), types: map[string]typeDecl{}}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(pkg.fset, filepath.Join(abs, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		pkg.files = append(pkg.files, f)
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				doc := ts.Doc
+				if doc == nil && len(gen.Specs) == 1 {
+					doc = gen.Doc
+				}
+				pkg.types[ts.Name.Name] = typeDecl{spec: ts, doc: doc, file: f}
+			}
+		}
+	}
+	if len(pkg.files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return pkg, nil
+}
+
+// routes — хендлеры с аннотацией @@route, отсортированные по пути и методу.
+func (p *pkgInfo) routes() ([]route, error) {
+	var routes []route
+	for _, f := range p.files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Doc == nil {
+				continue
+			}
+			r, ok, err := p.parseAnnotations(fn)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", p.fset.Position(fn.Pos()), err)
+			}
+			if !ok {
+				continue
+			}
+			r.file = f
+			routes = append(routes, r)
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].path != routes[j].path {
+			return routes[i].path < routes[j].path
+		}
+		return routes[i].method < routes[j].method
+	})
+	return routes, nil
+}
+
+// parseAnnotations — разбор строк @@ в doc-комментарии. ok=false, если хендлер не аннотирован.
+func (p *pkgInfo) parseAnnotations(fn *ast.FuncDecl) (r route, ok bool, err error) {
+	r = route{handler: fn.Name.Name, fn: fn}
+	for _, c := range fn.Doc.List {
+		line := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if !strings.HasPrefix(line, "@@") {
+			continue
+		}
+		name, rest, _ := strings.Cut(line, " ")
+		rest = strings.TrimSpace(rest)
+		switch name {
+		case "@@route":
+			fields := strings.Fields(rest)
+			if len(fields) != 2 {
+				return r, false, fmt.Errorf("@@route: expected \"METHOD /path\", got %q", rest)
+			}
+			r.method, r.path = strings.ToUpper(fields[0]), fields[1]
+			ok = true
+		case "@@desc":
+			r.desc = rest
+		case "@@accept":
+			format, typ, _ := strings.Cut(rest, " ")
+			if format != "json" {
+				return r, false, fmt.Errorf("@@accept: unsupported content type %q", format)
+			}
+			r.accept, r.acceptType = format, strings.TrimSpace(typ)
+		case "@@query", "@@header":
+			fields := strings.SplitN(rest, " ", 3)
+			if len(fields) < 2 {
+				return r, false, fmt.Errorf("%s: expected \"name type [description]\", got %q", name, rest)
+			}
+			q := param{in: strings.TrimPrefix(name, "@@"), name: fields[0], typ: fields[1]}
+			if len(fields) == 3 {
+				q.desc = strings.TrimSpace(fields[2])
+			}
+			r.params = append(r.params, q)
+		case "@@success":
+			rep, err := parseReply(rest)
+			if err != nil {
+				return r, false, fmt.Errorf("@@success: %w", err)
+			}
+			rep.typ, rep.desc = rep.desc, ""
+			r.success = &rep
+		case "@@error":
+			rep, err := parseReply(rest)
+			if err != nil {
+				return r, false, fmt.Errorf("@@error: %w", err)
+			}
+			// Первое слово описания может быть типом тела ответа вместо ErrorResponse.
+			if word, desc, _ := strings.Cut(rep.desc, " "); p.isStruct(word) {
+				rep.typ, rep.desc = word, strings.TrimSpace(desc)
+			}
+			r.errors = append(r.errors, rep)
+		default:
+			return r, false, fmt.Errorf("unknown annotation %s", name)
+		}
+	}
+	if ok && r.success == nil {
+		return r, false, fmt.Errorf("@@route %s %s has no @@success", r.method, r.path)
+	}
+	return r, ok, nil
+}
+
+// parseReply — "CODE остаток".
+func parseReply(s string) (reply, error) {
+	code, rest, _ := strings.Cut(s, " ")
+	n, err := strconv.Atoi(code)
+	if err != nil || http.StatusText(n) == "" {
+		return reply{}, fmt.Errorf("invalid status code %q", code)
+	}
+	return reply{code: n, desc: strings.TrimSpace(rest)}, nil
+}
+
+func (p *pkgInfo) isStruct(name string) bool {
+	t, ok := p.types[name]
+	if !ok {
+		return false
+	}
+	_, ok = t.spec.Type.(*ast.StructType)
+	return ok
+}
+
+// operation — операция OpenAPI по аннотациям; используемые типы добавляются в components через b.
+func (p *pkgInfo) operation(r route, b *schemaBuilder) (*Operation, error) {
+	op := &Operation{
+		OperationID: r.handler,
+		Summary:     r.desc,
+		Responses:   map[string]*Response{},
+	}
+	for _, name := range pathParams(r.path) {
+		op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	for _, q := range r.params {
+		s, err := basicSchema(q.typ)
+		if err != nil {
+			return nil, fmt.Errorf("@@%s %s: %w", q.in, q.name, err)
+		}
+		op.Parameters = append(op.Parameters, Parameter{Name: q.name, In: q.in, Description: q.desc, Schema: s})
+	}
+
+	if r.accept != "" {
+		s, err := p.requestBody(r, b)
+		if err != nil {
+			return nil, fmt.Errorf("request body: %w", err)
+		}
+		op.RequestBody = &RequestBody{Required: true, Content: jsonContent(s)}
+	}
+
+	success := &Response{Description: http.StatusText(r.success.code)}
+	if r.success.typ != "" {
+		s, err := b.reply(r.file, r.success.typ)
+		if err != nil {
+			return nil, fmt.Errorf("@@success: %w", err)
+		}
+		success.Content = jsonContent(s)
+	}
+	op.Responses[strconv.Itoa(r.success.code)] = success
+
+	for _, e := range r.errors {
+		code := strconv.Itoa(e.code)
+		if prev, ok := op.Responses[code]; ok {
+			// Несколько @@error с одним кодом — одно описание через "; ".
+			prev.Description += "; " + e.desc
+			continue
+		}
+		typ := e.typ
+		if typ == "" {
+			typ = errorSchema
+		}
+		s, err := b.reply(r.file, typ)
+		if err != nil {
+			return nil, fmt.Errorf("@@error %d: %w", e.code, err)
+		}
+		desc := e.desc
+		if desc == "" {
+			desc = http.StatusText(e.code)
+		}
+		op.Responses[code] = &Response{Description: desc, Content: jsonContent(s)}
+	}
+	return op, nil
+}
+
+// boundType — тип переменной, в которую хендлер разбирает тело: var req T; c.ShouldBindJSON(&req).
+// requestBody — схема тела запроса: тип из @@accept или тип переменной, в которую хендлер делает ShouldBindJSON.
+// -- Явный тип нужен хендлерам, которые читают тело сами (например, потоком).
+func (p *pkgInfo) requestBody(r route, b *schemaBuilder) (*Schema, error) {
+	if r.acceptType != "" {
+		return b.reply(r.file, r.acceptType)
+	}
+	typ, file, err := boundType(r)
+	if err != nil {
+		return nil, err
+	}
+	return b.expr(file, typ, true)
+}
+
+func boundType(r route) (ast.Expr, *ast.File, error) {
+	var target string
+	ast.Inspect(r.fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || target != "" {
+			return target == ""
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "ShouldBindJSON" || len(call.Args) != 1 {
+			return true
+		}
+		if u, ok := call.Args[0].(*ast.UnaryExpr); ok && u.Op == token.AND {
+			if id, ok := u.X.(*ast.Ident); ok {
+				target = id.Name
+			}
+		}
+		return true
+	})
+	if target == "" {
+		return nil, nil, fmt.Errorf("@@accept json, but no ShouldBindJSON(&v) in handler")
+	}
+	var typ ast.Expr
+	ast.Inspect(r.fn.Body, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || typ != nil {
+			return typ == nil
+		}
+		for _, name := range spec.Names {
+			if name.Name == target && spec.Type != nil {
+				typ = spec.Type
+			}
+		}
+		return true
+	})
+	if typ == nil {
+		return nil, nil, fmt.Errorf("@@accept json: variable %s must be declared as var %s T", target, target)
+	}
+	return typ, r.file, nil
+}
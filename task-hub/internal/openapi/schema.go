@@ -0,0 +1,301 @@
+package openapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/importer"
+	"go/types"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// schemaBuilder — перевод Go-типов в схемы; именованные структуры пакета попадают в components.schemas.
+type schemaBuilder struct {
+	pkg     *pkgInfo
+	schemas map[string]*Schema
+	imp     types.ImporterFrom
+	ext     map[string]*types.Package
+}
+
+// Типы из других пакетов с известным JSON-представлением.
+var wellKnown = map[string]Schema{
+	"time.Time":                   {Type: "string", Format: "date-time"},
+	"time.Duration":               {Type: "integer", Format: "int64"},
+	"github.com/google/uuid.UUID": {Type: "string", Format: "uuid"},
+}
+
+// reply — схема ответа: имя типа пакета, []имя (массив) или {"key": type, ...}.
+func (b *schemaBuilder) reply(file *ast.File, typ string) (*Schema, error) {
+	if strings.HasPrefix(typ, "{") {
+		return inlineObject(typ)
+	}
+	if elem, ok := strings.CutPrefix(typ, "[]"); ok {
+		items, err := b.reply(file, elem)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	}
+	if strings.ContainsAny(typ, " \t") {
+		return nil, fmt.Errorf("unexpected %q: expected type name or {\"key\": type}", typ)
+	}
+	return b.named(typ, false)
+}
+
+// named — ссылка на тип пакета; при первом использовании схема строится и добавляется в components.
+// -- request определяет обязательные поля: для тел запросов — по binding:"required", для ответов — поля без omitempty.
+func (b *schemaBuilder) named(name string, request bool) (*Schema, error) {
+	decl, ok := b.pkg.types[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %s", name)
+	}
+	if _, ok := decl.spec.Type.(*ast.StructType); !ok {
+		return b.expr(decl.file, decl.spec.Type, request)
+	}
+	if _, done := b.schemas[name]; !done {
+		b.schemas[name] = &Schema{} // заглушка на случай рекурсивных типов
+		s, err := b.expr(decl.file, decl.spec.Type, request)
+		if err != nil {
+			delete(b.schemas, name)
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		s.Description = docText(decl.doc, name)
+		b.schemas[name] = s
+	}
+	return &Schema{Ref: ref(name)}, nil
+}
+
+func (b *schemaBuilder) expr(file *ast.File, e ast.Expr, request bool) (*Schema, error) {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if s, err := basicSchema(t.Name); err == nil {
+			return s, nil
+		}
+		return b.named(t.Name, request)
+	case *ast.StarExpr:
+		return b.expr(file, t.X, request)
+	case *ast.ArrayType:
+		if id, ok := t.Elt.(*ast.Ident); ok && id.Name == "byte" {
+			return &Schema{Type: "string", Format: "byte"}, nil
+		}
+		items, err := b.expr(file, t.Elt, request)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case *ast.MapType:
+		values, err := b.expr(file, t.Value, request)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case *ast.InterfaceType:
+		return &Schema{}, nil
+	case *ast.SelectorExpr:
+		return b.external(file, t)
+	case *ast.StructType:
+		return b.object(file, t, request)
+	}
+	return nil, fmt.Errorf("unsupported type %T", e)
+}
+
+// object — схема структуры по json-тегам полей.
+func (b *schemaBuilder) object(file *ast.File, st *ast.StructType, request bool) (*Schema, error) {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			return nil, fmt.Errorf("embedded fields are not supported")
+		}
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			raw, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+			tag = reflect.StructTag(raw)
+		}
+		jsonName, jsonOpts, _ := strings.Cut(tag.Get("json"), ",")
+		if jsonName == "-" {
+			continue
+		}
+		prop, err := b.expr(file, f.Type, request)
+		if err != nil {
+			return nil, err
+		}
+		if prop.Ref == "" {
+			// В OpenAPI 3.0 соседние с $ref ключи игнорируются, поэтому описание только у встроенных схем.
+			prop.Description = docText(f.Doc, "")
+			if prop.Description == "" {
+				prop.Description = docText(f.Comment, "")
+			}
+		}
+		var required bool
+		if request {
+			required = strings.Contains(tag.Get("binding"), "required")
+		} else {
+			required = !strings.Contains(jsonOpts, "omitempty")
+		}
+		for _, n := range f.Names {
+			if !n.IsExported() {
+				continue
+			}
+			name := jsonName
+			if name == "" {
+				name = n.Name
+			}
+			s.Properties[name] = prop
+			if required {
+				s.Required = append(s.Required, name)
+			}
+		}
+	}
+	return s, nil
+}
+
+// external — тип из другого пакета: известный (time.Time, uuid.UUID) или именованный базовый тип
+// (models.TaskStatus), для которого перечисляются строковые константы этого типа.
+func (b *schemaBuilder) external(file *ast.File, sel *ast.SelectorExpr) (*Schema, error) {
+	pkgName, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil, fmt.Errorf("unsupported selector %T", sel.X)
+	}
+	path, err := importPath(file, pkgName.Name)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := wellKnown[path+"."+sel.Sel.Name]; ok {
+		return &s, nil
+	}
+
+	pkg, err := b.load(path)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := pkg.Scope().Lookup(sel.Sel.Name).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("%s.%s is not a type", path, sel.Sel.Name)
+	}
+	basic, ok := obj.Type().Underlying().(*types.Basic)
+	if !ok {
+		return nil, fmt.Errorf("%s.%s: only named basic types are supported from other packages", path, sel.Sel.Name)
+	}
+	s, err := basicSchema(basic.Name())
+	if err != nil {
+		return nil, err
+	}
+	if basic.Info()&types.IsString != 0 {
+		s.Enum = enumValues(pkg, obj.Type())
+	}
+	return s, nil
+}
+
+func (b *schemaBuilder) load(path string) (*types.Package, error) {
+	if pkg, ok := b.ext[path]; ok {
+		return pkg, nil
+	}
+	if b.imp == nil {
+		b.imp = importer.ForCompiler(b.pkg.fset, "source", nil).(types.ImporterFrom)
+		b.ext = map[string]*types.Package{}
+	}
+	pkg, err := b.imp.ImportFrom(path, b.pkg.dir, 0)
+	if err != nil {
+		return nil, fmt.Errorf("load %s: %w", path, err)
+	}
+	b.ext[path] = pkg
+	return pkg, nil
+}
+
+// enumValues — строковые константы типа typ в порядке объявления.
+func enumValues(pkg *types.Package, typ types.Type) []string {
+	var consts []*types.Const
+	for _, name := range pkg.Scope().Names() {
+		c, ok := pkg.Scope().Lookup(name).(*types.Const)
+		if ok && c.Exported() && types.Identical(c.Type(), typ) {
+			consts = append(consts, c)
+		}
+	}
+	sort.Slice(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+	values := make([]string, 0, len(consts))
+	for _, c := range consts {
+		values = append(values, constant.StringVal(c.Val()))
+	}
+	return values
+}
+
+func importPath(file *ast.File, name string) (string, error) {
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return "", err
+		}
+		local := path[strings.LastIndex(path, "/")+1:]
+		if imp.Name != nil {
+			local = imp.Name.Name
+		}
+		if local == name {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("unknown package %s", name)
+}
+
+// basicSchema — схема встроенного типа Go; также используется для типов в @@query и {"key": type}.
+func basicSchema(name string) (*Schema, error) {
+	switch name {
+	case "string":
+		return &Schema{Type: "string"}, nil
+	case "bool", "boolean":
+		return &Schema{Type: "boolean"}, nil
+	case "int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32", "integer":
+		return &Schema{Type: "integer"}, nil
+	case "int64", "uint64":
+		return &Schema{Type: "integer", Format: "int64"}, nil
+	case "float32", "float64", "number":
+		return &Schema{Type: "number"}, nil
+	case "any":
+		return &Schema{}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", name)
+}
+
+// inlineObject — разбор {"status": string, "count": integer}.
+func inlineObject(s string) (*Schema, error) {
+	body, ok := strings.CutPrefix(s, "{")
+	if body, ok = strings.CutSuffix(body, "}"); !ok {
+		return nil, fmt.Errorf("unterminated inline object %q", s)
+	}
+	obj := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, field := range strings.Split(body, ",") {
+		key, typ, ok := strings.Cut(field, ":")
+		if !ok {
+			return nil, fmt.Errorf("inline object %q: expected \"key\": type", s)
+		}
+		name, err := strconv.Unquote(strings.TrimSpace(key))
+		if err != nil {
+			return nil, fmt.Errorf("inline object %q: key must be quoted", s)
+		}
+		prop, err := basicSchema(strings.TrimSpace(typ))
+		if err != nil {
+			return nil, fmt.Errorf("inline object %q: %w", s, err)
+		}
+		obj.Properties[name] = prop
+		obj.Required = append(obj.Required, name)
+	}
+	return obj, nil
+}
+
+// docText — текст doc-комментария одной строкой без префикса "Name — ".
+func docText(doc *ast.CommentGroup, name string) string {
+	if doc == nil {
+		return ""
+	}
+	text := strings.Join(strings.Fields(doc.Text()), " ")
+	if name != "" {
+		text = strings.TrimPrefix(text, name+" — ")
+	}
+	text = strings.ReplaceAll(text, " -- ", " ")
+	return strings.TrimSpace(text)
+}
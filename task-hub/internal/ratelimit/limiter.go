@@ -0,0 +1,90 @@
+// Package ratelimit — ограничение частоты запросов к API по вызывающему (token bucket в памяти процесса).
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval — как часто из лимитера вычищаются корзины неактивных вызывающих.
+const sweepInterval = time.Minute
+
+// bucket — токены вызывающего на момент last.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter — у каждого ключа корзина на burst токенов, пополняемая со скоростью rate в секунду.
+// -- Запрос забирает токен; пустая корзина — отказ с временем до появления следующего токена.
+// -- rate <= 0 — без ограничений.
+type Limiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     int
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// New — конструктор; rate — запросов в секунду, burst — сколько запросов подряд допустимо сверх rate.
+func New(rate float64, burst int) *Limiter {
+	return &Limiter{rate: rate, burst: burst, buckets: make(map[string]*bucket), now: time.Now}
+}
+
+// SetLimit — смена лимита на лету (горячая перезагрузка конфига).
+// -- Накопленные токены сохраняются, но не больше нового burst.
+func (l *Limiter) SetLimit(rate float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rate, l.burst = rate, burst
+	for _, b := range l.buckets {
+		b.tokens = math.Min(b.tokens, float64(burst))
+	}
+}
+
+// Allow — забрать токен ключа key.
+// -- Возвращает false и через сколько появится следующий токен, если корзина пуста.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rate <= 0 {
+		return true, 0
+	}
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.buckets[key] = b
+	}
+	l.refill(b, now)
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// refill — пополнение корзины за время с прошлого обращения; вызывается под блокировкой.
+func (l *Limiter) refill(b *bucket, now time.Time) {
+	b.tokens = math.Min(float64(l.burst), b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+}
+
+// sweep — удаление полных корзин (вызывающий давно не обращался) не чаще раза в sweepInterval.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		l.refill(b, now)
+		if b.tokens >= float64(l.burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// clock — управляемое время для лимитера.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func TestLimiter_Burst(t *testing.T) {
+	c := &clock{t: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := New(2, 3)
+	l.now = c.now
+
+	// burst подряд, затем отказ с ожиданием до следующего токена; ключи независимы
+	for i := 0; i < 3; i++ {
+		ok, _ := l.Allow("a")
+		assert.True(t, ok, i)
+	}
+	ok, wait := l.Allow("a")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+	ok, _ = l.Allow("b")
+	assert.True(t, ok)
+
+	// Токены пополняются со скоростью rate
+	c.t = c.t.Add(500 * time.Millisecond)
+	ok, _ = l.Allow("a")
+	assert.True(t, ok)
+	ok, _ = l.Allow("a")
+	assert.False(t, ok)
+}
+
+func TestLimiter_SetLimit(t *testing.T) {
+	c := &clock{t: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := New(1, 5)
+	l.now = c.now
+	ok, _ := l.Allow("a")
+	assert.True(t, ok)
+
+	// Новый burst меньше накопленного — токены урезаются
+	l.SetLimit(1, 1)
+	ok, _ = l.Allow("a")
+	assert.True(t, ok)
+	ok, _ = l.Allow("a")
+	assert.False(t, ok)
+
+	// rate 0 — без ограничений
+	l.SetLimit(0, 0)
+	for i := 0; i < 10; i++ {
+		ok, _ = l.Allow("a")
+		assert.True(t, ok)
+	}
+}
+
+func TestLimiter_SweepsIdleBuckets(t *testing.T) {
+	c := &clock{t: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := New(1, 1)
+	l.now = c.now
+	_, _ = l.Allow("a")
+	_, _ = l.Allow("b")
+
+	c.t = c.t.Add(2 * sweepInterval)
+	_, _ = l.Allow("c")
+	assert.Len(t, l.buckets, 1)
+}
@@ -1,7 +1,11 @@
 package inmemory
 
 import (
+	"context"
+	"errors"
+	"slices"
 	"sync"
+	"sync/atomic"
 
 	"github.com/google/uuid"
 	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
@@ -11,52 +15,311 @@ import (
 
 // Убеждаемся, что InMemoryTaskRepository реализует интерфейс TaskRepository.
 var _ ports.TaskRepository = (*InMemoryTaskRepository)(nil)
+var _ ports.HealthChecker = (*InMemoryTaskRepository)(nil)
+var _ ports.Transactor = (*InMemoryTaskRepository)(nil)
 
+// ErrClosed — обращение к репозиторию после Close.
+var ErrClosed = errors.New("repository is closed")
+
+// InMemoryTaskRepository — задачи хранятся отдельно по рабочим пространствам,
+// поэтому задачу чужого пространства невозможно получить даже по известному id.
+// -- GetByID и List отдают копии: сервис меняет задачу на месте, и до Save хранимая задача не должна меняться
+// --    (иначе неудачное обновление остается в хранилище).
 type InMemoryTaskRepository struct {
-	mu    sync.RWMutex
-	tasks map[uuid.UUID]*models.Task
+	mu     sync.RWMutex
+	tasks  map[string]map[uuid.UUID]*models.Task // workspace -> id -> task
+	closed atomic.Bool
 }
 
+// Операции в памяти не блокируются надолго, поэтому отмену ctx (и закрытие
+// репозитория) достаточно проверить на входе в каждый метод.
+
 // Конструктор.
 func NewInMemoryTaskRepository() *InMemoryTaskRepository {
 	return &InMemoryTaskRepository{
-		tasks: make(map[uuid.UUID]*models.Task),
+		tasks: make(map[string]map[uuid.UUID]*models.Task),
+	}
+}
+
+// check — проверка на входе в метод: отмена ctx и закрытие репозитория.
+func (r *InMemoryTaskRepository) check(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if r.closed.Load() {
+		return ErrClosed
 	}
+	return nil
+}
+
+// Ping — хранилище в памяти доступно, пока жив процесс и репозиторий не закрыт.
+func (r *InMemoryTaskRepository) Ping(ctx context.Context) error {
+	return r.check(ctx)
 }
 
-func (r *InMemoryTaskRepository) Save(task *models.Task) error {
+// Close — закрытие репозитория при остановке: дальнейшие операции возвращают ErrClosed.
+// -- Дожидается завершения операций, которые уже держат блокировку.
+func (r *InMemoryTaskRepository) Close() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.tasks[task.ID()] = task
+	r.closed.Store(true)
 	return nil
 }
 
-func (r *InMemoryTaskRepository) GetByID(id uuid.UUID) (*models.Task, error) {
+func (r *InMemoryTaskRepository) Save(ctx context.Context, task *models.Task) error {
+	if err := r.check(ctx); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ws, ok := r.tasks[task.Workspace()]
+	if !ok {
+		ws = make(map[uuid.UUID]*models.Task)
+		r.tasks[task.Workspace()] = ws
+	}
+	ws[task.ID()] = task
+	return nil
+}
+
+func (r *InMemoryTaskRepository) GetByID(ctx context.Context, workspace string, id uuid.UUID) (*models.Task, error) {
+	if err := r.check(ctx); err != nil {
+		return nil, err
+	}
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	task, ok := r.tasks[id]
+	task, ok := r.tasks[workspace][id]
 	if !ok {
 		return nil, apperror.ErrRepoNotFound
 	}
-	return task, nil
+	return task.Clone(), nil
 }
 
-func (r *InMemoryTaskRepository) Delete(id uuid.UUID) error {
+func (r *InMemoryTaskRepository) Delete(ctx context.Context, workspace string, id uuid.UUID) error {
+	if err := r.check(ctx); err != nil {
+		return err
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	if _, ok := r.tasks[id]; !ok {
+	if _, ok := r.tasks[workspace][id]; !ok {
 		return apperror.ErrRepoNotFound
 	}
-	delete(r.tasks, id)
+	delete(r.tasks[workspace], id)
 	return nil
 }
 
-func (r *InMemoryTaskRepository) List() ([]*models.Task, error) {
+func (r *InMemoryTaskRepository) List(ctx context.Context, workspace string) ([]*models.Task, error) {
+	if err := r.check(ctx); err != nil {
+		return nil, err
+	}
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	result := make([]*models.Task, 0, len(r.tasks))
-	for _, t := range r.tasks {
-		result = append(result, t)
+	if workspace != "" {
+		result := make([]*models.Task, 0, len(r.tasks[workspace]))
+		for _, t := range r.tasks[workspace] {
+			result = append(result, t.Clone())
+		}
+		return result, nil
+	}
+	result := make([]*models.Task, 0)
+	for _, ws := range r.tasks {
+		for _, t := range ws {
+			result = append(result, t.Clone())
+		}
 	}
 	return result, nil
 }
+
+func (r *InMemoryTaskRepository) Count(ctx context.Context, workspace string) (int, error) {
+	if err := r.check(ctx); err != nil {
+		return 0, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	count := 0
+	for _, t := range r.tasks[workspace] {
+		if !t.IsDeleted() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// ListPage — страница задач пространства после курсора.
+// -- Индекса по времени создания нет: каждая страница выбирается проходом по пространству под блокировкой на чтение,
+// --    зато блокировка не держится, пока вызывающий обрабатывает страницу.
+func (r *InMemoryTaskRepository) ListPage(ctx context.Context, workspace string, after ports.TaskCursor, limit int) ([]*models.Task, error) {
+	if err := r.check(ctx); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return page(r.tasks[workspace], after, limit), nil
+}
+
+// page — до limit копий задач после курсора, в порядке курсора.
+func page(tasks map[uuid.UUID]*models.Task, after ports.TaskCursor, limit int) []*models.Task {
+	result := make([]*models.Task, 0)
+	for _, t := range tasks {
+		if ports.CursorOf(t).Compare(after) > 0 {
+			result = append(result, t)
+		}
+	}
+	slices.SortFunc(result, func(a, b *models.Task) int {
+		return ports.CursorOf(a).Compare(ports.CursorOf(b))
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	for i, t := range result {
+		result[i] = t.Clone()
+	}
+	return result
+}
+
+// InTx — транзакция "все или ничего" для пакетных операций.
+// -- 1. Репозиторий блокируется на запись на все время fn: транзакции идут последовательно,
+// --    остальные операции ждут фиксации. Внутри fn обращаться можно только к tx, не к самому репозиторию.
+// -- 2. Изменения копятся в tx поверх текущих данных, задачи отдаются копиями (сервис меняет их на месте),
+// --    поэтому при ошибке fn откат — просто отказ от накопленного.
+func (r *InMemoryTaskRepository) InTx(ctx context.Context, fn func(ctx context.Context, tx ports.TaskRepository) error) error {
+	if err := r.check(ctx); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Close мог пройти между проверкой и захватом блокировки.
+	if r.closed.Load() {
+		return ErrClosed
+	}
+	tx := &txRepository{base: r, changes: make(map[string]map[uuid.UUID]*models.Task)}
+	if err := fn(ctx, tx); err != nil {
+		return err
+	}
+	for workspace, changes := range tx.changes {
+		ws, ok := r.tasks[workspace]
+		if !ok {
+			ws = make(map[uuid.UUID]*models.Task)
+			r.tasks[workspace] = ws
+		}
+		for id, task := range changes {
+			if task == nil {
+				delete(ws, id)
+				continue
+			}
+			ws[id] = task
+		}
+	}
+	return nil
+}
+
+// txRepository — репозиторий внутри транзакции; блокировку держит InTx.
+type txRepository struct {
+	base    *InMemoryTaskRepository
+	changes map[string]map[uuid.UUID]*models.Task // workspace -> id -> task, nil — удалена
+}
+
+var _ ports.TaskRepository = (*txRepository)(nil)
+
+func (tx *txRepository) Save(ctx context.Context, task *models.Task) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	ws, ok := tx.changes[task.Workspace()]
+	if !ok {
+		ws = make(map[uuid.UUID]*models.Task)
+		tx.changes[task.Workspace()] = ws
+	}
+	ws[task.ID()] = task
+	return nil
+}
+
+func (tx *txRepository) GetByID(ctx context.Context, workspace string, id uuid.UUID) (*models.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	task, ok := tx.changes[workspace][id]
+	if !ok {
+		task, ok = tx.base.tasks[workspace][id]
+	}
+	if !ok || task == nil {
+		return nil, apperror.ErrRepoNotFound
+	}
+	return task.Clone(), nil
+}
+
+func (tx *txRepository) Delete(ctx context.Context, workspace string, id uuid.UUID) error {
+	if _, err := tx.GetByID(ctx, workspace, id); err != nil {
+		return err
+	}
+	ws, ok := tx.changes[workspace]
+	if !ok {
+		ws = make(map[uuid.UUID]*models.Task)
+		tx.changes[workspace] = ws
+	}
+	ws[id] = nil
+	return nil
+}
+
+func (tx *txRepository) List(ctx context.Context, workspace string) ([]*models.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result := make([]*models.Task, 0)
+	for ws := range tx.workspaces(workspace) {
+		for _, t := range tx.view(ws) {
+			result = append(result, t.Clone())
+		}
+	}
+	return result, nil
+}
+
+func (tx *txRepository) Count(ctx context.Context, workspace string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, t := range tx.view(workspace) {
+		if !t.IsDeleted() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (tx *txRepository) ListPage(ctx context.Context, workspace string, after ports.TaskCursor, limit int) ([]*models.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return page(tx.view(workspace), after, limit), nil
+}
+
+// workspaces — пространства для List: одно или все (пустой workspace), включая созданные в транзакции.
+func (tx *txRepository) workspaces(workspace string) map[string]struct{} {
+	if workspace != "" {
+		return map[string]struct{}{workspace: {}}
+	}
+	all := make(map[string]struct{}, len(tx.base.tasks))
+	for ws := range tx.base.tasks {
+		all[ws] = struct{}{}
+	}
+	for ws := range tx.changes {
+		all[ws] = struct{}{}
+	}
+	return all
+}
+
+// view — задачи пространства с учетом изменений транзакции.
+func (tx *txRepository) view(workspace string) map[uuid.UUID]*models.Task {
+	view := make(map[uuid.UUID]*models.Task, len(tx.base.tasks[workspace]))
+	for id, t := range tx.base.tasks[workspace] {
+		view[id] = t
+	}
+	for id, t := range tx.changes[workspace] {
+		if t == nil {
+			delete(view, id)
+			continue
+		}
+		view[id] = t
+	}
+	return view
+}
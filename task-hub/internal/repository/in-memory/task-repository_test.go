@@ -1,11 +1,15 @@
 package inmemory
 
 import (
+	"context"
+	"errors"
+	"slices"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/ports"
 )
 
 func TestInMemoryTaskRepository_SaveAndGetByID(t *testing.T) {
@@ -13,35 +17,35 @@ func TestInMemoryTaskRepository_SaveAndGetByID(t *testing.T) {
 	task, err := models.NewTask("Test", "desc", models.TaskPriorityLow)
 	assert.NoError(t, err)
 
-	err = repo.Save(task)
+	err = repo.Save(context.Background(), task)
 	assert.NoError(t, err)
 
-	got, err := repo.GetByID(task.ID())
+	got, err := repo.GetByID(context.Background(), task.Workspace(), task.ID())
 	assert.NoError(t, err)
 	assert.Equal(t, task, got)
 }
 
 func TestInMemoryTaskRepository_GetByID_NotFound(t *testing.T) {
 	repo := NewInMemoryTaskRepository()
-	_, err := repo.GetByID(uuid.New())
+	_, err := repo.GetByID(context.Background(), "", uuid.New())
 	assert.Error(t, err)
 }
 
 func TestInMemoryTaskRepository_Delete(t *testing.T) {
 	repo := NewInMemoryTaskRepository()
 	task, _ := models.NewTask("Test", "desc", models.TaskPriorityLow)
-	err := repo.Save(task)
+	err := repo.Save(context.Background(), task)
 	assert.NoError(t, err)
-	err = repo.Delete(task.ID())
+	err = repo.Delete(context.Background(), task.Workspace(), task.ID())
 	assert.NoError(t, err)
 
-	_, err = repo.GetByID(task.ID())
+	_, err = repo.GetByID(context.Background(), task.Workspace(), task.ID())
 	assert.Error(t, err)
 }
 
 func TestInMemoryTaskRepository_Delete_NotFound(t *testing.T) {
 	repo := NewInMemoryTaskRepository()
-	err := repo.Delete(uuid.New())
+	err := repo.Delete(context.Background(), "", uuid.New())
 	assert.Error(t, err)
 }
 
@@ -49,14 +53,188 @@ func TestInMemoryTaskRepository_List(t *testing.T) {
 	repo := NewInMemoryTaskRepository()
 	task1, _ := models.NewTask("T1", "desc1", models.TaskPriorityLow)
 	task2, _ := models.NewTask("T2", "desc2", models.TaskPriorityHigh)
-	err := repo.Save(task1)
+	err := repo.Save(context.Background(), task1)
 	assert.NoError(t, err)
-	err = repo.Save(task2)
+	err = repo.Save(context.Background(), task2)
 	assert.NoError(t, err)
 
-	list, err := repo.List()
+	list, err := repo.List(context.Background(), "")
 	assert.NoError(t, err)
 	assert.Len(t, list, 2)
 	assert.Contains(t, list, task1)
 	assert.Contains(t, list, task2)
 }
+
+func TestInMemoryTaskRepository_WorkspaceIsolation(t *testing.T) {
+	repo := NewInMemoryTaskRepository()
+	taskA, _ := models.NewTask("A", "desc", models.TaskPriorityLow)
+	_ = taskA.SetWorkspace("team-a")
+	taskB, _ := models.NewTask("B", "desc", models.TaskPriorityLow)
+	_ = taskB.SetWorkspace("team-b")
+	assert.NoError(t, repo.Save(context.Background(), taskA))
+	assert.NoError(t, repo.Save(context.Background(), taskB))
+
+	_, err := repo.GetByID(context.Background(), "team-b", taskA.ID())
+	assert.Error(t, err)
+	assert.Error(t, repo.Delete(context.Background(), "team-b", taskA.ID()))
+
+	list, err := repo.List(context.Background(), "team-a")
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Task{taskA}, list)
+
+	all, err := repo.List(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	_ = taskB.Delete()
+	count, err := repo.Count(context.Background(), "team-b")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestInMemoryTaskRepository_CancelledContext(t *testing.T) {
+	repo := NewInMemoryTaskRepository()
+	task, _ := models.NewTask("Test", "desc", models.TaskPriorityLow)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, repo.Save(ctx, task), context.Canceled)
+	_, err := repo.GetByID(ctx, "", task.ID())
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = repo.List(ctx, "")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestInMemoryTaskRepository_Close(t *testing.T) {
+	repo := NewInMemoryTaskRepository()
+	task, _ := models.NewTask("Test", "desc", models.TaskPriorityLow)
+	assert.NoError(t, repo.Save(context.Background(), task))
+	assert.NoError(t, repo.Ping(context.Background()))
+
+	assert.NoError(t, repo.Close())
+	assert.ErrorIs(t, repo.Ping(context.Background()), ErrClosed)
+	assert.ErrorIs(t, repo.Save(context.Background(), task), ErrClosed)
+	_, err := repo.List(context.Background(), "")
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func TestInMemoryTaskRepository_InTx(t *testing.T) {
+	repo := NewInMemoryTaskRepository()
+	existing, _ := models.NewTask("Existing", "", models.TaskPriorityLow)
+	assert.NoError(t, repo.Save(context.Background(), existing))
+	created, _ := models.NewTask("Created", "", models.TaskPriorityLow)
+
+	// 1. Ошибка fn — изменения отбрасываются, в том числе сделанные на месте
+	boom := errors.New("boom")
+	err := repo.InTx(context.Background(), func(ctx context.Context, tx ports.TaskRepository) error {
+		task, err := tx.GetByID(ctx, "", existing.ID())
+		assert.NoError(t, err)
+		assert.NoError(t, task.Start())
+		assert.NoError(t, tx.Save(ctx, task))
+		assert.NoError(t, tx.Save(ctx, created))
+		count, err := tx.Count(ctx, "")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+	got, err := repo.GetByID(context.Background(), "", existing.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, models.TaskStatusPending, got.Status())
+	_, err = repo.GetByID(context.Background(), "", created.ID())
+	assert.Error(t, err)
+
+	// 2. Успех — фиксируются все изменения, включая удаление
+	err = repo.InTx(context.Background(), func(ctx context.Context, tx ports.TaskRepository) error {
+		if err := tx.Save(ctx, created); err != nil {
+			return err
+		}
+		if err := tx.Delete(ctx, "", existing.ID()); err != nil {
+			return err
+		}
+		_, err := tx.GetByID(ctx, "", existing.ID())
+		assert.Error(t, err)
+		tasks, err := tx.List(ctx, "")
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 1)
+		return nil
+	})
+	assert.NoError(t, err)
+	tasks, err := repo.List(context.Background(), "")
+	assert.NoError(t, err)
+	if !assert.Len(t, tasks, 1) {
+		return
+	}
+	assert.Equal(t, created.ID(), tasks[0].ID())
+
+	// 3. После закрытия транзакции недоступны
+	assert.NoError(t, repo.Close())
+	assert.ErrorIs(t, repo.InTx(context.Background(), func(context.Context, ports.TaskRepository) error { return nil }), ErrClosed)
+}
+
+func TestInMemoryTaskRepository_ReadsReturnCopies(t *testing.T) {
+	repo := NewInMemoryTaskRepository()
+	task, _ := models.NewTask("Test", "desc", models.TaskPriorityLow)
+	assert.NoError(t, repo.Save(context.Background(), task))
+
+	// Изменения прочитанной задачи без Save в хранилище не попадают
+	got, err := repo.GetByID(context.Background(), task.Workspace(), task.ID())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, got.SetTitle("changed"))
+	assert.NoError(t, got.Start())
+	list, err := repo.List(context.Background(), "")
+	if !assert.NoError(t, err) || !assert.Len(t, list, 1) {
+		return
+	}
+	assert.NoError(t, list[0].SetTitle("changed again"))
+
+	stored, _ := repo.GetByID(context.Background(), task.Workspace(), task.ID())
+	assert.Equal(t, "Test", stored.Title())
+	assert.Equal(t, models.TaskStatusPending, stored.Status())
+	assert.Empty(t, stored.Intervals())
+}
+
+func TestInMemoryTaskRepository_ListPage(t *testing.T) {
+	repo := NewInMemoryTaskRepository()
+	ctx := context.Background()
+	var want []*models.Task
+	for i := 0; i < 5; i++ {
+		task, _ := models.NewTask("T", "", models.TaskPriorityLow)
+		_ = task.SetWorkspace("team-a")
+		if i == 4 {
+			_ = task.Delete()
+		}
+		assert.NoError(t, repo.Save(ctx, task))
+		want = append(want, task)
+	}
+	other, _ := models.NewTask("Other", "", models.TaskPriorityLow)
+	_ = other.SetWorkspace("team-b")
+	assert.NoError(t, repo.Save(ctx, other))
+	slices.SortFunc(want, func(a, b *models.Task) int { return ports.CursorOf(a).Compare(ports.CursorOf(b)) })
+
+	// Страницы по 2 от нулевого курсора: все задачи пространства (и корзина) по порядку, последняя — неполная
+	var got []*models.Task
+	var cursor ports.TaskCursor
+	for pages := 0; ; pages++ {
+		if !assert.Less(t, pages, 3) {
+			return
+		}
+		page, err := repo.ListPage(ctx, "team-a", cursor, 2)
+		if !assert.NoError(t, err) {
+			return
+		}
+		got = append(got, page...)
+		if len(page) < 2 {
+			break
+		}
+		cursor = ports.CursorOf(page[len(page)-1])
+	}
+	assert.Equal(t, want, got)
+
+	// Курсор последней задачи — пустая страница
+	page, err := repo.ListPage(ctx, "team-a", ports.CursorOf(want[4]), 2)
+	assert.NoError(t, err)
+	assert.Empty(t, page)
+}
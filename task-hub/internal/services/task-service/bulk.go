@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/ports"
+)
+
+// txKey — ключ контекста с транзакцией пакетной операции.
+type txKey struct{}
+
+// txState — транзакция пакетной операции.
+// -- 1. repo — репозиторий транзакции, им пользуются все методы сервиса, вызванные с этим контекстом.
+// -- 2. onCommit — метрики и логи изменений: выполняются только после фиксации, при откате — нет.
+type txState struct {
+	repo     ports.TaskRepository
+	onCommit []func()
+}
+
+// errBatchFailed — хотя бы один элемент атомарного пакета завершился ошибкой, транзакция откатывается.
+var errBatchFailed = errors.New("batch failed")
+
+// repository — репозиторий для текущего вызова: внутри транзакции — транзакционный.
+func (s *TaskService) repository(ctx context.Context) ports.TaskRepository {
+	if tx, ok := ctx.Value(txKey{}).(*txState); ok {
+		return tx.repo
+	}
+	return s.repo
+}
+
+// inTx — fn в транзакции хранилища: методы сервиса, вызванные с контекстом fn, работают с ней.
+// -- Отложенные afterCommit действия выполняются только после успешной фиксации.
+func (s *TaskService) inTx(ctx context.Context, transactor ports.Transactor, fn func(ctx context.Context) error) error {
+	tx := &txState{}
+	err := transactor.InTx(ctx, func(ctx context.Context, repo ports.TaskRepository) error {
+		tx.repo = repo
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+	if err != nil {
+		return err
+	}
+	for _, fn := range tx.onCommit {
+		fn()
+	}
+	return nil
+}
+
+// atomically — проверка и запись, которые не должны разойтись под конкурентной нагрузкой (квота, уникальность id).
+// -- 1. Хранилище с транзакциями — fn в отдельной транзакции; уже внутри транзакции — в ней же.
+// -- 2. Хранилище без транзакций гарантий не дает: fn вызывается как есть.
+func (s *TaskService) atomically(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*txState); ok {
+		return fn(ctx)
+	}
+	transactor, ok := s.repo.(ports.Transactor)
+	if !ok {
+		return fn(ctx)
+	}
+	return s.inTx(ctx, transactor, fn)
+}
+
+// afterCommit — выполнить fn сразу, а внутри транзакции — после ее фиксации.
+func afterCommit(ctx context.Context, fn func()) {
+	if tx, ok := ctx.Value(txKey{}).(*txState); ok {
+		tx.onCommit = append(tx.onCommit, fn)
+		return
+	}
+	fn()
+}
+
+// ChangeStatus — перевод задачи в указанный статус.
+// -- in_progress из "paused" — возобновление, иначе старт; pending из "failed" — перезапуск, иначе переоткрытие.
+func (s *TaskService) ChangeStatus(ctx context.Context, id uuid.UUID, status models.TaskStatus, reason string) error {
+	_, err := s.changeStatus(ctx, id, status, reason)
+	return err
+}
+
+func (s *TaskService) changeStatus(ctx context.Context, id uuid.UUID, status models.TaskStatus, reason string) (*models.Task, error) {
+	task, err := s.getActive(ctx, id, auth.ActionUpdate)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyStatus(task, status, reason); err != nil {
+		return nil, domainError(err)
+	}
+	if err := s.save(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// applyStatus — доменный переход задачи в статус.
+func applyStatus(task *models.Task, status models.TaskStatus, reason string) error {
+	switch status {
+	case models.TaskStatusInProgress:
+		if task.Status() == models.TaskStatusPaused {
+			return task.Resume()
+		}
+		return task.Start()
+	case models.TaskStatusPaused:
+		return task.Pause()
+	case models.TaskStatusCompleted:
+		return task.Complete()
+	case models.TaskStatusCancelled:
+		return task.Cancel()
+	case models.TaskStatusFailed:
+		return task.Fail()
+	case models.TaskStatusPending:
+		if task.Status() == models.TaskStatusFailed {
+			return task.Retry(reason)
+		}
+		return task.Reopen(reason)
+	}
+	return apperror.ErrServiceValidation.WithField("status", fmt.Sprintf("cannot change status to %q", status))
+}
+
+// CreateTasks — пакетное создание задач; результаты в порядке черновиков.
+// -- Без atomic каждая задача создается независимо, ошибки — по элементам.
+// -- С atomic — все в одной транзакции хранилища: при ошибке хотя бы одного элемента не создается ничего.
+func (s *TaskService) CreateTasks(ctx context.Context, drafts []ports.TaskDraft, atomic bool) ([]ports.BulkResult, error) {
+	return s.bulk(ctx, len(drafts), atomic, func(ctx context.Context, i int) (*models.Task, error) {
+		d := drafts[i]
+		return s.CreateTask(ctx, d.Title, d.Description, d.Priority, d.Deadline)
+	})
+}
+
+// ChangeStatuses — пакетная смена статусов; семантика atomic как у CreateTasks.
+func (s *TaskService) ChangeStatuses(ctx context.Context, changes []ports.StatusChange, atomic bool) ([]ports.BulkResult, error) {
+	return s.bulk(ctx, len(changes), atomic, func(ctx context.Context, i int) (*models.Task, error) {
+		c := changes[i]
+		return s.changeStatus(ctx, c.ID, c.Status, c.Reason)
+	})
+}
+
+// bulk — выполнение n элементов пакета по порядку.
+// -- 1. Ошибка возвращается только для пакета целиком (отмена запроса, нет поддержки транзакций, сбой хранилища).
+// -- 2. В атомарном режиме при ошибках элементов остальные получают ErrServiceBatchAborted, изменения откатываются.
+func (s *TaskService) bulk(ctx context.Context, n int, atomic bool, do func(ctx context.Context, i int) (*models.Task, error)) ([]ports.BulkResult, error) {
+	results := make([]ports.BulkResult, n)
+	if !atomic {
+		for i := range results {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			results[i].Task, results[i].Err = do(ctx, i)
+		}
+		return results, nil
+	}
+
+	transactor, ok := s.repo.(ports.Transactor)
+	if !ok {
+		return nil, apperror.ErrServiceTxUnsupported
+	}
+	err := s.inTx(ctx, transactor, func(txCtx context.Context) error {
+		failed := false
+		// Ошибки собираются по всем элементам, а не до первой: клиенту видно, что исправить.
+		for i := range results {
+			if err := txCtx.Err(); err != nil {
+				return err
+			}
+			results[i].Task, results[i].Err = do(txCtx, i)
+			failed = failed || results[i].Err != nil
+		}
+		if failed {
+			return errBatchFailed
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, errBatchFailed):
+		for i := range results {
+			if results[i].Err == nil {
+				results[i] = ports.BulkResult{Err: apperror.ErrServiceBatchAborted}
+			}
+		}
+		return results, nil
+	case err != nil:
+		return nil, err
+	}
+	return results, nil
+}
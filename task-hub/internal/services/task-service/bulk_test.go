@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/ports"
+	inmemory "github.com/vagonaizer/workmate/task-hub/internal/repository/in-memory"
+	"github.com/vagonaizer/workmate/task-hub/internal/services/task-service/mocks"
+	"github.com/vagonaizer/workmate/task-hub/pkg/logger"
+)
+
+// savedMetrics — метрики, считающие вызовы TaskSaved.
+type savedMetrics struct{ saved int }
+
+func (m *savedMetrics) TaskSaved(*models.Task)        { m.saved++ }
+func (m *savedMetrics) TaskRemoved(string, uuid.UUID) {}
+
+func TestTaskService_ChangeStatus(t *testing.T) {
+	service := NewTaskService(inmemory.NewInMemoryTaskRepository(), auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+	ctx := context.Background()
+	task, err := service.CreateTask(ctx, "Test", "", models.TaskPriorityLow, time.Time{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// in_progress из паузы — возобновление, pending из ошибки — перезапуск
+	for _, status := range []models.TaskStatus{models.TaskStatusInProgress, models.TaskStatusPaused, models.TaskStatusInProgress, models.TaskStatusFailed, models.TaskStatusPending} {
+		assert.NoError(t, service.ChangeStatus(ctx, task.ID(), status, "again"), status)
+		got, err := service.GetTask(ctx, task.ID())
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, status, got.Status())
+	}
+	got, _ := service.GetTask(ctx, task.ID())
+	assert.Equal(t, "again", got.Reason())
+
+	err = service.ChangeStatus(ctx, task.ID(), models.TaskStatusCompleted, "")
+	assert.True(t, errors.Is(err, apperror.ErrServiceConflict))
+	err = service.ChangeStatus(ctx, task.ID(), models.TaskStatusDeleted, "")
+	assert.True(t, errors.Is(err, apperror.ErrServiceValidation))
+}
+
+func TestTaskService_CreateTasks(t *testing.T) {
+	metrics := &savedMetrics{}
+	service := NewTaskService(inmemory.NewInMemoryTaskRepository(), auth.NewPolicy(true), Quotas{}, logger.Nop(), metrics)
+	ctx := context.Background()
+
+	results, err := service.CreateTasks(ctx, []ports.TaskDraft{{Title: "a"}, {Title: ""}, {Title: "c"}}, false)
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 3) {
+		return
+	}
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "a", results[0].Task.Title())
+	assert.True(t, errors.Is(results[1].Err, models.ErrInvalidTitle))
+	assert.Nil(t, results[1].Task)
+	assert.NoError(t, results[2].Err)
+	assert.Equal(t, 2, metrics.saved)
+
+	tasks, _ := service.ListTasks(ctx, false)
+	assert.Len(t, tasks, 2)
+}
+
+func TestTaskService_CreateTasks_Atomic(t *testing.T) {
+	metrics := &savedMetrics{}
+	service := NewTaskService(inmemory.NewInMemoryTaskRepository(), auth.NewPolicy(true), Quotas{Default: 3}, logger.Nop(), metrics)
+	ctx := context.Background()
+
+	// 1. Квота считается с учетом задач, созданных в той же транзакции: четвертая не помещается, откатываются все
+	drafts := []ports.TaskDraft{{Title: "a"}, {Title: "b"}, {Title: "c"}, {Title: "d"}}
+	results, err := service.CreateTasks(ctx, drafts, true)
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 4) {
+		return
+	}
+	for _, r := range results[:3] {
+		assert.True(t, errors.Is(r.Err, apperror.ErrServiceBatchAborted))
+		assert.Nil(t, r.Task)
+	}
+	assert.True(t, errors.Is(results[3].Err, apperror.ErrServiceQuotaExceeded))
+	tasks, _ := service.ListTasks(ctx, false)
+	assert.Empty(t, tasks)
+	assert.Zero(t, metrics.saved)
+
+	// 2. Без ошибок — создаются все, метрики после фиксации
+	results, err = service.CreateTasks(ctx, drafts[:3], true)
+	assert.NoError(t, err)
+	for _, r := range results {
+		assert.NoError(t, r.Err)
+	}
+	tasks, _ = service.ListTasks(ctx, false)
+	assert.Len(t, tasks, 3)
+	assert.Equal(t, 3, metrics.saved)
+}
+
+func TestTaskService_ChangeStatuses_Atomic(t *testing.T) {
+	service := NewTaskService(inmemory.NewInMemoryTaskRepository(), auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+	ctx := context.Background()
+	a, _ := service.CreateTask(ctx, "a", "", models.TaskPriorityLow, time.Time{})
+	b, _ := service.CreateTask(ctx, "b", "", models.TaskPriorityLow, time.Time{})
+
+	changes := []ports.StatusChange{
+		{ID: a.ID(), Status: models.TaskStatusInProgress},
+		{ID: b.ID(), Status: models.TaskStatusCompleted}, // из pending завершить нельзя
+		{ID: uuid.New(), Status: models.TaskStatusCancelled},
+	}
+	results, err := service.ChangeStatuses(ctx, changes, true)
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 3) {
+		return
+	}
+	assert.True(t, errors.Is(results[0].Err, apperror.ErrServiceBatchAborted))
+	assert.True(t, errors.Is(results[1].Err, apperror.ErrServiceConflict))
+	assert.True(t, errors.Is(results[2].Err, apperror.ErrRepoNotFound))
+	got, _ := service.GetTask(ctx, a.ID())
+	assert.Equal(t, models.TaskStatusPending, got.Status())
+
+	// Тот же пакет без atomic применяет то, что возможно
+	results, err = service.ChangeStatuses(ctx, changes, false)
+	assert.NoError(t, err)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, models.TaskStatusInProgress, results[0].Task.Status())
+	assert.Error(t, results[1].Err)
+	assert.Error(t, results[2].Err)
+}
+
+func TestTaskService_Bulk_AtomicUnsupported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	service := NewTaskService(mockRepo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+
+	_, err := service.CreateTasks(context.Background(), []ports.TaskDraft{{Title: "a"}}, true)
+	assert.True(t, errors.Is(err, apperror.ErrServiceTxUnsupported))
+
+	// Без atomic транзакции не нужны
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	results, err := service.CreateTasks(context.Background(), []ports.TaskDraft{{Title: "a"}}, false)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/ports"
+	"github.com/vagonaizer/workmate/task-hub/pkg/logger"
+)
+
+// Purger — фоновый процесс очистки корзины.
+// -- Раз в interval безвозвратно удаляет задачи, пролежавшие в корзине дольше retention.
+type Purger struct {
+	service ports.TaskService
+	logger  *logger.Logger
+
+	mu        sync.Mutex
+	retention time.Duration
+	interval  time.Duration
+	reset     chan struct{} // сигнал циклу очистки, что интервал изменился
+
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+	running atomic.Bool
+
+	// ctx проходов очистки; при остановке отменяется досрочно, только если текущий проход не уложился в срок.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Конструктор.
+func NewPurger(service ports.TaskService, retention, interval time.Duration, logger *logger.Logger) *Purger {
+	p := &Purger{
+		service:   service,
+		retention: retention,
+		interval:  interval,
+		logger:    logger,
+		reset:     make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+	}
+	p.ctx, p.cancel = context.WithCancel(auth.WithPrincipal(context.Background(), auth.System))
+	return p
+}
+
+// Start — запускает очистку в отдельной горутине.
+func (p *Purger) Start() {
+	p.wg.Add(1)
+	p.running.Store(true)
+	go func() {
+		defer p.wg.Done()
+		defer p.running.Store(false)
+		ticker := time.NewTicker(p.schedule().interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-p.reset:
+				ticker.Reset(p.schedule().interval)
+			case <-ticker.C:
+				select {
+				case <-p.stop: // остановка запрошена во время прошлого прохода — новый не начинаем
+					return
+				default:
+				}
+				p.purge()
+			}
+		}
+	}()
+}
+
+// Stop — останавливает очистку и дожидается завершения текущего прохода.
+func (p *Purger) Stop() {
+	_ = p.Shutdown(context.Background())
+}
+
+// Shutdown — остановка с ограничением по времени.
+// -- 1. Новые проходы не запускаются, текущему дается закончить.
+// -- 2. Если ctx истек раньше, текущий проход отменяется, и возвращается ctx.Err().
+func (p *Purger) Shutdown(ctx context.Context) error {
+	p.once.Do(func() { close(p.stop) })
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// SetSchedule — смена срока хранения и интервала очистки на лету.
+func (p *Purger) SetSchedule(retention, interval time.Duration) {
+	p.mu.Lock()
+	p.retention, p.interval = retention, interval
+	p.mu.Unlock()
+	select {
+	case p.reset <- struct{}{}:
+	default: // сигнал уже ждет обработки
+	}
+}
+
+type purgeSchedule struct {
+	retention, interval time.Duration
+}
+
+func (p *Purger) schedule() purgeSchedule {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return purgeSchedule{retention: p.retention, interval: p.interval}
+}
+
+// Running — работает ли фоновая очистка (для проверки готовности).
+func (p *Purger) Running() bool {
+	return p.running.Load()
+}
+
+func (p *Purger) purge() {
+	purged, err := p.service.PurgeDeleted(p.ctx, p.schedule().retention)
+	if err != nil {
+		p.logger.Error("Ошибка очистки корзины: %v", err)
+		return
+	}
+	if purged > 0 {
+		p.logger.Info("Из корзины удалено задач: %d", purged)
+	}
+}
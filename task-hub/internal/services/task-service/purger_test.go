@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/services/task-service/mocks"
+	"github.com/vagonaizer/workmate/task-hub/pkg/logger"
+)
+
+func TestPurger_ShutdownWaitsForPass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	svc := NewTaskService(mockRepo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+
+	started := make(chan struct{})
+	mockRepo.EXPECT().List(gomock.Any(), "").DoAndReturn(func(ctx context.Context, _ string) ([]any, error) {
+		close(started)
+		time.Sleep(20 * time.Millisecond) // проход короче таймаута остановки
+		return nil, nil
+	}).Times(1)
+
+	p := NewPurger(svc, time.Hour, time.Millisecond, logger.Nop())
+	p.Start()
+	<-started
+	assert.NoError(t, p.Shutdown(context.Background()))
+	assert.False(t, p.Running())
+}
+
+func TestPurger_ShutdownCancelsPassOnTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	svc := NewTaskService(mockRepo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+
+	started := make(chan struct{})
+	mockRepo.EXPECT().List(gomock.Any(), "").DoAndReturn(func(ctx context.Context, _ string) ([]any, error) {
+		close(started)
+		<-ctx.Done() // проход длиннее таймаута: завершится только по отмене
+		return nil, ctx.Err()
+	}).Times(1)
+
+	p := NewPurger(svc, time.Hour, time.Millisecond, logger.Nop())
+	p.Start()
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, p.Shutdown(ctx), context.DeadlineExceeded)
+	assert.False(t, p.Running())
+}
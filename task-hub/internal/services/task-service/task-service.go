@@ -1,12 +1,19 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
 	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
 	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
 	"github.com/vagonaizer/workmate/task-hub/internal/domain/ports"
+	"github.com/vagonaizer/workmate/task-hub/pkg/logger"
 )
 
 // TaskService — сервисный слой для работы с задачами.
@@ -15,165 +22,481 @@ import (
 var _ ports.TaskService = (*TaskService)(nil)
 
 type TaskService struct {
-	repo ports.TaskRepository
+	repo     ports.TaskRepository
+	policy   *auth.Policy
+	quotasMu sync.RWMutex
+	quotas   Quotas
+	logger   *logger.Logger
+	metrics  ports.TaskMetrics
 }
 
-// Конструктор принимающий на вход репозиторий.
-func NewTaskService(repo ports.TaskRepository) *TaskService {
-	return &TaskService{repo: repo}
+// Quotas — лимиты на количество задач в рабочем пространстве, 0 — без ограничений.
+type Quotas struct {
+	Default      int            // лимит для пространств без индивидуальной квоты
+	PerWorkspace map[string]int // индивидуальные квоты, ключи в нижнем регистре
 }
 
+// Limit — квота рабочего пространства.
+// -- Имя пространства сравнивается без учета регистра: viper приводит ключи tenancy.quotas к нижнему.
+func (q Quotas) Limit(workspace string) int {
+	if limit, ok := q.PerWorkspace[strings.ToLower(workspace)]; ok {
+		return limit
+	}
+	return q.Default
+}
+
+// Конструктор принимающий на вход репозиторий, политику доступа, квоты, логгер и метрики.
+// -- nil-логгер и nil-метрики заменяются заглушками.
+func NewTaskService(repo ports.TaskRepository, policy *auth.Policy, quotas Quotas, log *logger.Logger, metrics ports.TaskMetrics) *TaskService {
+	if log == nil {
+		log = logger.Nop()
+	}
+	if metrics == nil {
+		metrics = nopMetrics{}
+	}
+	return &TaskService{repo: repo, policy: policy, quotas: quotas, logger: log, metrics: metrics}
+}
+
+// nopMetrics — метрики-заглушка.
+type nopMetrics struct{}
+
+func (nopMetrics) TaskSaved(*models.Task)        {}
+func (nopMetrics) TaskRemoved(string, uuid.UUID) {}
+
 // CreateTask — создание новой задачи.
-func (s *TaskService) CreateTask(title, description string, priority models.TaskPriority, deadline time.Time) (*models.Task, error) {
+func (s *TaskService) CreateTask(ctx context.Context, title, description string, priority models.TaskPriority, deadline time.Time) (*models.Task, error) {
+	if err := s.policy.Authorize(ctx, auth.ActionCreate, nil); err != nil {
+		return nil, err
+	}
 	task, err := models.NewTask(title, description, priority)
 	if err != nil {
-		return nil, apperror.ErrServiceValidation
+		return nil, domainError(err)
 	}
 	if !deadline.IsZero() {
 		if err := task.SetDeadline(deadline); err != nil {
-			return nil, apperror.ErrServiceValidation
+			return nil, domainError(err)
 		}
 	}
-	if err := s.repo.Save(task); err != nil {
-		return nil, apperror.ErrRepoSaveFailed
+	workspace := auth.WorkspaceFrom(ctx)
+	if err := task.SetWorkspace(workspace); err != nil {
+		return nil, domainError(err)
+	}
+	task.SetCreatedBy(actor(ctx))
+	// Подсчет и запись — одной транзакцией, иначе параллельные запросы проходят проверку квоты вместе.
+	err = s.atomically(ctx, func(ctx context.Context) error {
+		if err := s.checkQuota(ctx, workspace); err != nil {
+			return err
+		}
+		if err := s.repository(ctx).Save(ctx, task); err != nil {
+			return apperror.ErrRepoSaveFailed.Wrap(err)
+		}
+		afterCommit(ctx, func() {
+			s.metrics.TaskSaved(task)
+			s.log(ctx).With("task_id", task.ID().String(), "workspace", workspace).Info("Создана задача")
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return task, nil
 }
 
 // GetTask — получение задачи по идентификатору.
-func (s *TaskService) GetTask(id uuid.UUID) (*models.Task, error) {
-	task, err := s.repo.GetByID(id)
+// -- Задачи из корзины считаются отсутствующими.
+func (s *TaskService) GetTask(ctx context.Context, id uuid.UUID) (*models.Task, error) {
+	return s.getActive(ctx, id, auth.ActionRead)
+}
+
+// DeleteTask — перемещение задачи в корзину (soft delete).
+func (s *TaskService) DeleteTask(ctx context.Context, id uuid.UUID) error {
+	task, err := s.getActive(ctx, id, auth.ActionDelete)
 	if err != nil {
-		return nil, apperror.ErrRepoNotFound
+		return err
 	}
-	return task, nil
+	if err := task.Delete(); err != nil {
+		return domainError(err)
+	}
+	if err := s.save(ctx, task); err != nil {
+		return err
+	}
+	s.log(ctx).With("task_id", id.String()).Info("Задача перемещена в корзину")
+	return nil
+}
+
+// RestoreTask — восстановление задачи из корзины.
+func (s *TaskService) RestoreTask(ctx context.Context, id uuid.UUID) error {
+	task, err := s.repository(ctx).GetByID(ctx, auth.WorkspaceFrom(ctx), id)
+	if err != nil {
+		return notFound(err)
+	}
+	if err := s.policy.Authorize(ctx, auth.ActionRestore, task); err != nil {
+		return err
+	}
+	if err := task.Restore(); err != nil {
+		return domainError(err)
+	}
+	return s.save(ctx, task)
+}
+
+// PurgeDeleted — безвозвратно удаляет задачи, пролежавшие в корзине дольше retention.
+// -- 1. Кандидаты — по снимку всех рабочих пространств (очистка системная).
+// -- 2. Каждая задача перечитывается и удаляется в одной транзакции: восстановленная после снимка задача
+// (или удаленная в корзину заново) остается.
+// -- Возвращает количество удаленных задач.
+func (s *TaskService) PurgeDeleted(ctx context.Context, retention time.Duration) (int, error) {
+	if err := s.policy.Authorize(ctx, auth.ActionPurge, nil); err != nil {
+		return 0, err
+	}
+	tasks, err := s.repository(ctx).List(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+	threshold := models.Now().Add(-retention)
+	expired := func(t *models.Task) bool {
+		return t.IsDeleted() && !t.DeletedAt().After(threshold)
+	}
+	purged := 0
+	for _, t := range tasks {
+		if !expired(t) {
+			continue
+		}
+		removed := false
+		err := s.atomically(ctx, func(ctx context.Context) error {
+			repo := s.repository(ctx)
+			current, err := repo.GetByID(ctx, t.Workspace(), t.ID())
+			if errors.Is(err, apperror.ErrRepoNotFound) {
+				return nil
+			}
+			if err != nil || !expired(current) {
+				return err
+			}
+			if err := repo.Delete(ctx, t.Workspace(), t.ID()); err != nil {
+				return err
+			}
+			removed = true
+			afterCommit(ctx, func() { s.metrics.TaskRemoved(t.Workspace(), t.ID()) })
+			return nil
+		})
+		if err != nil {
+			return purged, err
+		}
+		if removed {
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// CheckpointRunning — возвращает все задачи в работе в "pending" (см. models.Task.Checkpoint) при остановке сервиса.
+// -- 1. Проходит по всем рабочим пространствам одной транзакцией: задачи возвращаются все или ни одной.
+// -- 2. Возвращает количество возвращенных задач.
+func (s *TaskService) CheckpointRunning(ctx context.Context) (int, error) {
+	if err := s.policy.Authorize(ctx, auth.ActionCheckpoint, nil); err != nil {
+		return 0, err
+	}
+	checkpointed := 0
+	err := s.atomically(ctx, func(ctx context.Context) error {
+		checkpointed = 0
+		tasks, err := s.repository(ctx).List(ctx, "")
+		if err != nil {
+			return err
+		}
+		for _, t := range tasks {
+			if t.Status() != models.TaskStatusInProgress {
+				continue
+			}
+			if err := t.Checkpoint(); err != nil {
+				return domainError(err)
+			}
+			if err := s.save(ctx, t); err != nil {
+				return err
+			}
+			checkpointed++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return checkpointed, nil
+}
+
+// ListTasks — получение списка задач.
+// -- Задачи из корзины возвращаются только при includeDeleted.
+func (s *TaskService) ListTasks(ctx context.Context, includeDeleted bool) ([]*models.Task, error) {
+	if err := s.policy.Authorize(ctx, auth.ActionRead, nil); err != nil {
+		return nil, err
+	}
+	tasks, err := s.repository(ctx).List(ctx, auth.WorkspaceFrom(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if includeDeleted {
+		return tasks, nil
+	}
+	result := make([]*models.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if !t.IsDeleted() {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+// domainError — оборачивает доменную ошибку в AppError, не теряя причину.
+// -- 1. Недопустимый переход статуса — конфликт.
+// -- 2. Невалидные поля — ошибка валидации с подробностями по полю.
+// -- Причина остается доступной через errors.Is(err, models.ErrInvalidDeadline) и т.д.
+func domainError(err error) error {
+	field := models.FieldOf(err)
+	switch field {
+	case "":
+		return err
+	case "status":
+		return apperror.ErrServiceConflict.Wrap(err).WithField(field, err.Error())
+	default:
+		return apperror.ErrServiceValidation.Wrap(err).WithField(field, err.Error())
+	}
+}
+
+// SetQuotas — смена квот на лету (горячая перезагрузка конфига).
+func (s *TaskService) SetQuotas(quotas Quotas) {
+	s.quotasMu.Lock()
+	defer s.quotasMu.Unlock()
+	s.quotas = quotas
+}
+
+// checkQuota — проверка квоты рабочего пространства перед созданием задачи.
+// -- Вызывается внутри atomically вместе с записью задачи.
+func (s *TaskService) checkQuota(ctx context.Context, workspace string) error {
+	s.quotasMu.RLock()
+	limit := s.quotas.Limit(workspace)
+	s.quotasMu.RUnlock()
+	if limit <= 0 {
+		return nil
+	}
+	count, err := s.repository(ctx).Count(ctx, workspace)
+	if err != nil {
+		return err
+	}
+	if count >= limit {
+		return apperror.ErrServiceQuotaExceeded.WithField("workspace", fmt.Sprintf("%s: limit of %d tasks reached", workspace, limit))
+	}
+	return nil
+}
+
+// notFound — ошибку поиска задачи отдаем как "не найдено",
+// кроме отмены/дедлайна запроса: их не маскируем, чтобы транспорт ответил корректно.
+func notFound(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return apperror.ErrRepoNotFound
+}
+
+// log — логгер из контекста запроса (с request_id), иначе общий логгер сервиса.
+func (s *TaskService) log(ctx context.Context) *logger.Logger {
+	return logger.FromContext(ctx, s.logger)
+}
+
+// save — сохранение измененной задачи с фиксацией, кто ее изменил.
+func (s *TaskService) save(ctx context.Context, task *models.Task) error {
+	task.SetUpdatedBy(actor(ctx))
+	if err := s.repository(ctx).Save(ctx, task); err != nil {
+		return err
+	}
+	afterCommit(ctx, func() { s.metrics.TaskSaved(task) })
+	return nil
 }
 
-// DeleteTask — удаление задачи по идентификатору.
-func (s *TaskService) DeleteTask(id uuid.UUID) error {
-	return s.repo.Delete(id)
+// actor — кто выполняет операцию: subject аутентифицированного вызывающего из контекста.
+// -- Для анонимных запросов (аутентификация выключена) — пустая строка.
+func actor(ctx context.Context) string {
+	if p, ok := auth.PrincipalFrom(ctx); ok {
+		return p.Subject
+	}
+	return ""
 }
 
-// ListTasks — получение списка всех задач.
-func (s *TaskService) ListTasks() ([]*models.Task, error) {
-	return s.repo.List()
+// getActive — получение задачи, не находящейся в корзине, с проверкой прав на действие.
+func (s *TaskService) getActive(ctx context.Context, id uuid.UUID, action auth.Action) (*models.Task, error) {
+	task, err := s.repository(ctx).GetByID(ctx, auth.WorkspaceFrom(ctx), id)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if task.IsDeleted() {
+		return nil, apperror.ErrRepoNotFound
+	}
+	if err := s.policy.Authorize(ctx, action, task); err != nil {
+		return nil, err
+	}
+	return task, nil
 }
 
 // StartTask — переводит задачу в статус "в работе".
-func (s *TaskService) StartTask(id uuid.UUID) error {
-	task, err := s.repo.GetByID(id)
+func (s *TaskService) StartTask(ctx context.Context, id uuid.UUID) error {
+	task, err := s.getActive(ctx, id, auth.ActionUpdate)
 	if err != nil {
-		return apperror.ErrRepoNotFound
+		return err
 	}
 	if err := task.Start(); err != nil {
+		return domainError(err)
+	}
+	return s.save(ctx, task)
+}
+
+// PauseTask — ставит задачу на паузу.
+func (s *TaskService) PauseTask(ctx context.Context, id uuid.UUID) error {
+	task, err := s.getActive(ctx, id, auth.ActionUpdate)
+	if err != nil {
 		return err
 	}
-	return s.repo.Save(task)
+	if err := task.Pause(); err != nil {
+		return domainError(err)
+	}
+	return s.save(ctx, task)
+}
+
+// ResumeTask — возобновляет задачу после паузы.
+func (s *TaskService) ResumeTask(ctx context.Context, id uuid.UUID) error {
+	task, err := s.getActive(ctx, id, auth.ActionUpdate)
+	if err != nil {
+		return err
+	}
+	if err := task.Resume(); err != nil {
+		return domainError(err)
+	}
+	return s.save(ctx, task)
 }
 
 // CompleteTask — завершает задачу.
-func (s *TaskService) CompleteTask(id uuid.UUID) error {
-	task, err := s.repo.GetByID(id)
+func (s *TaskService) CompleteTask(ctx context.Context, id uuid.UUID) error {
+	task, err := s.getActive(ctx, id, auth.ActionUpdate)
 	if err != nil {
-		return apperror.ErrRepoNotFound
+		return err
 	}
 	if err := task.Complete(); err != nil {
-		return err
+		return domainError(err)
 	}
-	return s.repo.Save(task)
+	return s.save(ctx, task)
 }
 
 // CancelTask — отменяет задачу.
-func (s *TaskService) CancelTask(id uuid.UUID) error {
-	task, err := s.repo.GetByID(id)
+func (s *TaskService) CancelTask(ctx context.Context, id uuid.UUID) error {
+	task, err := s.getActive(ctx, id, auth.ActionUpdate)
 	if err != nil {
-		return apperror.ErrRepoNotFound
+		return err
 	}
 	if err := task.Cancel(); err != nil {
-		return err
+		return domainError(err)
 	}
-	return s.repo.Save(task)
+	return s.save(ctx, task)
 }
 
 // FailTask — переводит задачу в статус "ошибка при выполнении".
-func (s *TaskService) FailTask(id uuid.UUID) error {
-	task, err := s.repo.GetByID(id)
+func (s *TaskService) FailTask(ctx context.Context, id uuid.UUID) error {
+	task, err := s.getActive(ctx, id, auth.ActionUpdate)
 	if err != nil {
-		return apperror.ErrRepoNotFound
+		return err
 	}
 	if err := task.Fail(); err != nil {
-		return err
+		return domainError(err)
 	}
-	return s.repo.Save(task)
+	return s.save(ctx, task)
 }
 
-// DeleteDomainTask — переводит задачу в статус "удалена" (soft delete).
-func (s *TaskService) DeleteDomainTask(id uuid.UUID) error {
-	task, err := s.repo.GetByID(id)
+// ReopenTask — переоткрывает завершенную или отмененную задачу.
+func (s *TaskService) ReopenTask(ctx context.Context, id uuid.UUID, reason string) error {
+	task, err := s.getActive(ctx, id, auth.ActionUpdate)
 	if err != nil {
-		return apperror.ErrRepoNotFound
+		return err
 	}
-	if err := task.Delete(); err != nil {
+	if err := task.Reopen(reason); err != nil {
+		return domainError(err)
+	}
+	return s.save(ctx, task)
+}
+
+// RetryTask — перезапускает задачу, завершившуюся ошибкой.
+func (s *TaskService) RetryTask(ctx context.Context, id uuid.UUID, reason string) error {
+	task, err := s.getActive(ctx, id, auth.ActionUpdate)
+	if err != nil {
 		return err
 	}
-	return s.repo.Save(task)
+	if err := task.Retry(reason); err != nil {
+		return domainError(err)
+	}
+	return s.save(ctx, task)
 }
 
 // SetDeadline — устанавливает дедлайн задачи.
-func (s *TaskService) SetDeadline(id uuid.UUID, deadline time.Time) error {
-	task, err := s.repo.GetByID(id)
+func (s *TaskService) SetDeadline(ctx context.Context, id uuid.UUID, deadline time.Time) error {
+	task, err := s.getActive(ctx, id, auth.ActionUpdate)
 	if err != nil {
-		return apperror.ErrRepoNotFound
+		return err
 	}
 	if err := task.SetDeadline(deadline); err != nil {
+		return domainError(err)
+	}
+	return s.save(ctx, task)
+}
+
+// AssignTask — назначает исполнителя задачи (пустая строка снимает назначение).
+func (s *TaskService) AssignTask(ctx context.Context, id uuid.UUID, assignee string) error {
+	task, err := s.getActive(ctx, id, auth.ActionUpdate)
+	if err != nil {
 		return err
 	}
-	return s.repo.Save(task)
+	if err := task.SetAssignee(assignee); err != nil {
+		return domainError(err)
+	}
+	return s.save(ctx, task)
 }
 
 // UpdateTitle — изменяет заголовок задачи.
-func (s *TaskService) UpdateTitle(id uuid.UUID, title string) error {
-	task, err := s.repo.GetByID(id)
+func (s *TaskService) UpdateTitle(ctx context.Context, id uuid.UUID, title string) error {
+	task, err := s.getActive(ctx, id, auth.ActionUpdate)
 	if err != nil {
-		return apperror.ErrRepoNotFound
+		return err
 	}
 	if err := task.SetTitle(title); err != nil {
-		return apperror.ErrServiceValidation
+		return domainError(err)
 	}
-	return s.repo.Save(task)
+	return s.save(ctx, task)
 }
 
 // UpdateDescription — изменяет описание задачи.
-func (s *TaskService) UpdateDescription(id uuid.UUID, description string) error {
-	task, err := s.repo.GetByID(id)
+func (s *TaskService) UpdateDescription(ctx context.Context, id uuid.UUID, description string) error {
+	task, err := s.getActive(ctx, id, auth.ActionUpdate)
 	if err != nil {
-		return apperror.ErrRepoNotFound
+		return err
 	}
 	task.SetDescription(description)
-	return s.repo.Save(task)
+	return s.save(ctx, task)
 }
 
 // UpdatePriority — изменяет приоритет задачи.
-func (s *TaskService) UpdatePriority(id uuid.UUID, priority models.TaskPriority) error {
-	task, err := s.repo.GetByID(id)
+func (s *TaskService) UpdatePriority(ctx context.Context, id uuid.UUID, priority models.TaskPriority) error {
+	task, err := s.getActive(ctx, id, auth.ActionUpdate)
 	if err != nil {
-		return apperror.ErrRepoNotFound
+		return err
 	}
 	// Добавь метод SetPriority в models.Task, если его нет
 	if err := task.SetPriority(priority); err != nil {
-		return apperror.ErrServiceValidation
+		return domainError(err)
 	}
-	return s.repo.Save(task)
+	return s.save(ctx, task)
 }
 
 // UpdateDeadline — изменяет дедлайн задачи.
-func (s *TaskService) UpdateDeadline(id uuid.UUID, deadline time.Time) error {
-	task, err := s.repo.GetByID(id)
+func (s *TaskService) UpdateDeadline(ctx context.Context, id uuid.UUID, deadline time.Time) error {
+	task, err := s.getActive(ctx, id, auth.ActionUpdate)
 	if err != nil {
-		return apperror.ErrRepoNotFound
+		return err
 	}
 	if err := task.SetDeadline(deadline); err != nil {
-		return apperror.ErrServiceValidation
+		return domainError(err)
 	}
-	return s.repo.Save(task)
+	return s.save(ctx, task)
 }
@@ -1,14 +1,22 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
 	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+	inmemory "github.com/vagonaizer/workmate/task-hub/internal/repository/in-memory"
 	"github.com/vagonaizer/workmate/task-hub/internal/services/task-service/mocks"
+	"github.com/vagonaizer/workmate/task-hub/pkg/logger"
 )
 
 func TestTaskService_CreateTask(t *testing.T) {
@@ -16,7 +24,7 @@ func TestTaskService_CreateTask(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockTaskRepository(ctrl)
-	service := NewTaskService(mockRepo)
+	service := NewTaskService(mockRepo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
 
 	title := "Test"
 	desc := "desc"
@@ -26,27 +34,150 @@ func TestTaskService_CreateTask(t *testing.T) {
 	task, _ := models.NewTask(title, desc, priority)
 	err := task.SetDeadline(deadline)
 	assert.NoError(t, err)
-	mockRepo.EXPECT().Save(gomock.Any()).Return(nil)
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
 
-	created, err := service.CreateTask(title, desc, priority, deadline)
+	created, err := service.CreateTask(context.Background(), title, desc, priority, deadline)
 	assert.NoError(t, err)
 	assert.Equal(t, title, created.Title())
 	assert.Equal(t, desc, created.Description())
 	assert.Equal(t, priority, created.Priority())
 }
 
+func TestTaskService_CreateTask_PastDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	service := NewTaskService(mockRepo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+
+	_, err := service.CreateTask(context.Background(), "Test", "desc", models.TaskPriorityLow, time.Now().Add(-time.Hour))
+	assert.True(t, errors.Is(err, apperror.ErrServiceValidation))
+	assert.True(t, errors.Is(err, models.ErrInvalidDeadline))
+
+	var appErr *apperror.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Contains(t, appErr.Details["deadline"], "deadline must be in the future")
+}
+
+func TestTaskService_StartTask_InvalidTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	service := NewTaskService(mockRepo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+
+	task, _ := models.NewTask("Test", "desc", models.TaskPriorityLow)
+	_ = task.Cancel()
+	mockRepo.EXPECT().GetByID(gomock.Any(), auth.DefaultWorkspace, task.ID()).Return(task, nil)
+
+	err := service.StartTask(context.Background(), task.ID())
+	assert.True(t, errors.Is(err, apperror.ErrServiceConflict))
+	assert.True(t, errors.Is(err, models.ErrInvalidStatus))
+}
+
+func TestTaskService_PauseResume(t *testing.T) {
+	type op func(s *TaskService, ctx context.Context, id uuid.UUID) error
+	var (
+		start    op = (*TaskService).StartTask
+		pause    op = (*TaskService).PauseTask
+		resume   op = (*TaskService).ResumeTask
+		complete op = (*TaskService).CompleteTask
+	)
+	tests := []struct {
+		name   string
+		setup  []op
+		action op
+		want   models.TaskStatus // статус после action; при ошибке — прежний
+		ok     bool
+	}{
+		{name: "пауза задачи в работе", setup: []op{start}, action: pause, want: models.TaskStatusPaused, ok: true},
+		{name: "возобновление после паузы", setup: []op{start, pause}, action: resume, want: models.TaskStatusInProgress, ok: true},
+		{name: "завершение после возобновления", setup: []op{start, pause, resume}, action: complete, want: models.TaskStatusCompleted, ok: true},
+		{name: "пауза не начатой задачи", action: pause, want: models.TaskStatusPending},
+		{name: "пауза завершенной задачи", setup: []op{start, complete}, action: pause, want: models.TaskStatusCompleted},
+		{name: "повторная пауза", setup: []op{start, pause}, action: pause, want: models.TaskStatusPaused},
+		{name: "возобновление задачи в работе", setup: []op{start}, action: resume, want: models.TaskStatusInProgress},
+		{name: "возобновление завершенной задачи", setup: []op{start, complete}, action: resume, want: models.TaskStatusCompleted},
+		{name: "завершение задачи на паузе", setup: []op{start, pause}, action: complete, want: models.TaskStatusPaused},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewTaskService(inmemory.NewInMemoryTaskRepository(), auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+			ctx := context.Background()
+			task, err := service.CreateTask(ctx, "Test", "desc", models.TaskPriorityLow, time.Time{})
+			if !assert.NoError(t, err) {
+				return
+			}
+			for _, step := range tt.setup {
+				if !assert.NoError(t, step(service, ctx, task.ID())) {
+					return
+				}
+			}
+
+			err = tt.action(service, ctx, task.ID())
+			if tt.ok {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, apperror.ErrServiceConflict)
+				assert.ErrorIs(t, err, models.ErrInvalidStatus)
+			}
+			got, err := service.GetTask(ctx, task.ID())
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got.Status())
+			}
+		})
+	}
+}
+
+// failingSaveRepository — хранилище в памяти, запись в которое можно сломать.
+type failingSaveRepository struct {
+	*inmemory.InMemoryTaskRepository
+	fail bool
+}
+
+func (r *failingSaveRepository) Save(ctx context.Context, task *models.Task) error {
+	if r.fail {
+		return errors.New("disk full")
+	}
+	return r.InMemoryTaskRepository.Save(ctx, task)
+}
+
+func TestTaskService_FailedUpdateLeavesStoredTask(t *testing.T) {
+	repo := &failingSaveRepository{InMemoryTaskRepository: inmemory.NewInMemoryTaskRepository()}
+	service := NewTaskService(repo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+	ctx := context.Background()
+	task, err := service.CreateTask(ctx, "Test", "desc", models.TaskPriorityLow, time.Time{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// Сервис меняет задачу до записи; запись не удалась — в хранилище прежнее состояние
+	repo.fail = true
+	assert.Error(t, service.UpdateTitle(ctx, task.ID(), "changed"))
+	assert.Error(t, service.StartTask(ctx, task.ID()))
+	repo.fail = false
+
+	got, err := service.GetTask(ctx, task.ID())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Test", got.Title())
+	assert.Equal(t, models.TaskStatusPending, got.Status())
+	assert.Empty(t, got.Intervals())
+}
+
 func TestTaskService_GetTask(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockTaskRepository(ctrl)
-	service := NewTaskService(mockRepo)
+	service := NewTaskService(mockRepo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
 
 	id := uuid.New()
 	task, _ := models.NewTask("Test", "desc", models.TaskPriorityLow)
-	mockRepo.EXPECT().GetByID(id).Return(task, nil)
+	mockRepo.EXPECT().GetByID(gomock.Any(), auth.DefaultWorkspace, id).Return(task, nil)
 
-	got, err := service.GetTask(id)
+	got, err := service.GetTask(context.Background(), id)
 	assert.NoError(t, err)
 	assert.Equal(t, task, got)
 }
@@ -56,13 +187,158 @@ func TestTaskService_DeleteTask(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockTaskRepository(ctrl)
-	service := NewTaskService(mockRepo)
+	service := NewTaskService(mockRepo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
 
-	id := uuid.New()
-	mockRepo.EXPECT().Delete(id).Return(nil)
+	task, _ := models.NewTask("Test", "desc", models.TaskPriorityLow)
+	mockRepo.EXPECT().GetByID(gomock.Any(), auth.DefaultWorkspace, task.ID()).Return(task, nil)
+	mockRepo.EXPECT().Save(gomock.Any(), task).Return(nil)
 
-	err := service.DeleteTask(id)
+	err := service.DeleteTask(context.Background(), task.ID())
 	assert.NoError(t, err)
+	assert.Equal(t, models.TaskStatusDeleted, task.Status())
+}
+
+func TestTaskService_RestoreTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	service := NewTaskService(mockRepo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+
+	task, _ := models.NewTask("Test", "desc", models.TaskPriorityLow)
+	_ = task.Delete()
+	mockRepo.EXPECT().GetByID(gomock.Any(), auth.DefaultWorkspace, task.ID()).Return(task, nil)
+	mockRepo.EXPECT().Save(gomock.Any(), task).Return(nil)
+
+	err := service.RestoreTask(context.Background(), task.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, models.TaskStatusPending, task.Status())
+}
+
+func TestTaskService_GetTask_Deleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	service := NewTaskService(mockRepo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+
+	task, _ := models.NewTask("Test", "desc", models.TaskPriorityLow)
+	_ = task.Delete()
+	mockRepo.EXPECT().GetByID(gomock.Any(), auth.DefaultWorkspace, task.ID()).Return(task, nil)
+
+	_, err := service.GetTask(context.Background(), task.ID())
+	assert.Error(t, err)
+}
+
+func TestTaskService_PurgeDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	service := NewTaskService(mockRepo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+
+	alive, _ := models.NewTask("Alive", "desc", models.TaskPriorityLow)
+	trashed, _ := models.NewTask("Trashed", "desc", models.TaskPriorityLow)
+	_ = trashed.Delete()
+	mockRepo.EXPECT().List(gomock.Any(), "").Return([]*models.Task{alive, trashed}, nil).Times(2)
+	mockRepo.EXPECT().GetByID(gomock.Any(), trashed.Workspace(), trashed.ID()).Return(trashed, nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), trashed.Workspace(), trashed.ID()).Return(nil)
+
+	// Корзина еще не "протухла" — ничего не удаляем.
+	purged, err := service.PurgeDeleted(context.Background(), time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, purged)
+
+	purged, err = service.PurgeDeleted(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+}
+
+// listHookRepository — хранилище в памяти, которое вызывает onList сразу после снимка List.
+type listHookRepository struct {
+	*inmemory.InMemoryTaskRepository
+	onList func()
+}
+
+func (r *listHookRepository) List(ctx context.Context, workspace string) ([]*models.Task, error) {
+	tasks, err := r.InMemoryTaskRepository.List(ctx, workspace)
+	if r.onList != nil {
+		r.onList()
+	}
+	return tasks, err
+}
+
+func TestTaskService_PurgeDeleted_RestoredDuringPurge(t *testing.T) {
+	repo := &listHookRepository{InMemoryTaskRepository: inmemory.NewInMemoryTaskRepository()}
+	service := NewTaskService(repo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+	ctx := context.Background()
+	restored, _ := service.CreateTask(ctx, "Restored", "desc", models.TaskPriorityLow, time.Time{})
+	expired, _ := service.CreateTask(ctx, "Expired", "desc", models.TaskPriorityLow, time.Time{})
+	assert.NoError(t, service.DeleteTask(ctx, restored.ID()))
+	assert.NoError(t, service.DeleteTask(ctx, expired.ID()))
+
+	// Задачу восстанавливают, пока очистка идет по снимку корзины
+	repo.onList = func() {
+		repo.onList = nil
+		assert.NoError(t, service.RestoreTask(ctx, restored.ID()))
+	}
+	purged, err := service.PurgeDeleted(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	got, err := service.GetTask(ctx, restored.ID())
+	if assert.NoError(t, err) {
+		assert.Equal(t, models.TaskStatusPending, got.Status())
+	}
+	_, err = repo.GetByID(ctx, auth.DefaultWorkspace, expired.ID())
+	assert.ErrorIs(t, err, apperror.ErrRepoNotFound)
+}
+
+func TestTaskService_CheckpointRunning(t *testing.T) {
+	repo := inmemory.NewInMemoryTaskRepository()
+	service := NewTaskService(repo, auth.NewPolicy(false), Quotas{}, logger.Nop(), nil)
+	system := auth.WithPrincipal(context.Background(), auth.System)
+
+	// Задачи в разных пространствах и статусах; в pending возвращаются только задачи в работе
+	ids := make(map[string]uuid.UUID)
+	for _, c := range []struct{ workspace, title string }{{"team-a", "running"}, {"team-b", "running"}, {"team-a", "paused"}, {"team-a", "pending"}} {
+		ctx := auth.WithWorkspace(system, c.workspace)
+		task, err := service.CreateTask(ctx, c.title, "desc", models.TaskPriorityLow, time.Time{})
+		if !assert.NoError(t, err) {
+			return
+		}
+		ids[c.workspace+"/"+c.title] = task.ID()
+		if c.title != "pending" {
+			assert.NoError(t, service.StartTask(ctx, task.ID()))
+		}
+		if c.title == "paused" {
+			assert.NoError(t, service.PauseTask(ctx, task.ID()))
+		}
+	}
+
+	member := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "bob", Roles: []string{auth.RoleMember}})
+	_, err := service.CheckpointRunning(member)
+	assert.ErrorIs(t, err, apperror.ErrServiceForbidden)
+
+	checkpointed, err := service.CheckpointRunning(system)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, checkpointed)
+	for key, want := range map[string]models.TaskStatus{
+		"team-a/running": models.TaskStatusPending,
+		"team-b/running": models.TaskStatusPending,
+		"team-a/paused":  models.TaskStatusPaused,
+		"team-a/pending": models.TaskStatusPending,
+	} {
+		workspace, _, _ := strings.Cut(key, "/")
+		task, err := service.GetTask(auth.WithWorkspace(system, workspace), ids[key])
+		if !assert.NoError(t, err, key) {
+			continue
+		}
+		assert.Equal(t, want, task.Status(), key)
+		for _, i := range task.Intervals() {
+			assert.False(t, i.End.IsZero(), key)
+		}
+	}
 }
 
 func TestTaskService_ListTasks(t *testing.T) {
@@ -70,14 +346,20 @@ func TestTaskService_ListTasks(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockTaskRepository(ctrl)
-	service := NewTaskService(mockRepo)
+	service := NewTaskService(mockRepo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
 	task1, _ := models.NewTask("T1", "desc1", models.TaskPriorityLow)
 	task2, _ := models.NewTask("T2", "desc2", models.TaskPriorityHigh)
-	mockRepo.EXPECT().List().Return([]*models.Task{task1, task2}, nil)
+	task3, _ := models.NewTask("T3", "desc3", models.TaskPriorityHigh)
+	_ = task3.Delete()
+	mockRepo.EXPECT().List(gomock.Any(), auth.DefaultWorkspace).Return([]*models.Task{task1, task2, task3}, nil).Times(2)
 
-	list, err := service.ListTasks()
+	list, err := service.ListTasks(context.Background(), false)
 	assert.NoError(t, err)
 	assert.Len(t, list, 2)
+
+	list, err = service.ListTasks(context.Background(), true)
+	assert.NoError(t, err)
+	assert.Len(t, list, 3)
 }
 
 func TestTaskService_GetTask_NotFound(t *testing.T) {
@@ -85,10 +367,143 @@ func TestTaskService_GetTask_NotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockTaskRepository(ctrl)
-	service := NewTaskService(mockRepo)
+	service := NewTaskService(mockRepo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
 	id := uuid.New()
-	mockRepo.EXPECT().GetByID(id).Return(nil, assert.AnError)
+	mockRepo.EXPECT().GetByID(gomock.Any(), auth.DefaultWorkspace, id).Return(nil, assert.AnError)
 
-	_, err := service.GetTask(id)
+	_, err := service.GetTask(context.Background(), id)
 	assert.Error(t, err)
 }
+
+func TestTaskService_Policy_MemberCannotModifyOthersTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	service := NewTaskService(mockRepo, auth.NewPolicy(false), Quotas{}, logger.Nop(), nil)
+
+	task, _ := models.NewTask("Test", "desc", models.TaskPriorityLow)
+	task.SetCreatedBy("alice")
+	mockRepo.EXPECT().GetByID(gomock.Any(), auth.DefaultWorkspace, task.ID()).Return(task, nil).Times(2)
+	mockRepo.EXPECT().Save(gomock.Any(), task).Return(nil)
+
+	bob := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "bob", Roles: []string{auth.RoleMember}})
+	err := service.UpdateTitle(bob, task.ID(), "Hijacked")
+	assert.True(t, errors.Is(err, apperror.ErrServiceForbidden))
+	assert.Equal(t, "Test", task.Title())
+
+	alice := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "alice", Roles: []string{auth.RoleMember}})
+	assert.NoError(t, service.UpdateTitle(alice, task.ID(), "Renamed"))
+	assert.Equal(t, "alice", task.UpdatedBy())
+}
+
+func TestTaskService_CreateTask_Workspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	service := NewTaskService(mockRepo, auth.NewPolicy(true), Quotas{Default: 10, PerWorkspace: map[string]int{"team-a": 1}}, logger.Nop(), nil)
+
+	ctx := auth.WithWorkspace(context.Background(), "team-a")
+	mockRepo.EXPECT().Count(gomock.Any(), "team-a").Return(0, nil)
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	task, err := service.CreateTask(ctx, "First", "", models.TaskPriorityLow, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, "team-a", task.Workspace())
+
+	mockRepo.EXPECT().Count(gomock.Any(), "team-a").Return(1, nil)
+	_, err = service.CreateTask(ctx, "Second", "", models.TaskPriorityLow, time.Time{})
+	assert.True(t, errors.Is(err, apperror.ErrServiceQuotaExceeded))
+}
+
+func TestQuotas_Limit(t *testing.T) {
+	quotas := Quotas{Default: 10, PerWorkspace: map[string]int{"team-a": 1}}
+	tests := []struct {
+		workspace string
+		want      int
+	}{
+		{"team-a", 1},
+		{"Team-A", 1}, // ключи квот из конфига viper отдает в нижнем регистре
+		{"team-b", 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.workspace, func(t *testing.T) {
+			assert.Equal(t, tt.want, quotas.Limit(tt.workspace))
+		})
+	}
+}
+
+// barrierRepository — хранилище в памяти, в котором Count и GetByID отвечают, только когда прочитали все n вызовов.
+// -- Если проверка (квота, наличие задачи) идет мимо транзакции, все запросы видят одно и то же состояние
+// --    и проходят ее вместе.
+type barrierRepository struct {
+	*inmemory.InMemoryTaskRepository
+	arrived sync.WaitGroup
+}
+
+func (r *barrierRepository) Count(ctx context.Context, workspace string) (int, error) {
+	count, err := r.InMemoryTaskRepository.Count(ctx, workspace)
+	r.arrived.Done()
+	r.arrived.Wait()
+	return count, err
+}
+
+func (r *barrierRepository) GetByID(ctx context.Context, workspace string, id uuid.UUID) (*models.Task, error) {
+	task, err := r.InMemoryTaskRepository.GetByID(ctx, workspace, id)
+	r.arrived.Done()
+	r.arrived.Wait()
+	return task, err
+}
+
+func TestTaskService_CreateTask_QuotaUnderConcurrency(t *testing.T) {
+	const n = 10
+	repo := &barrierRepository{InMemoryTaskRepository: inmemory.NewInMemoryTaskRepository()}
+	repo.arrived.Add(n)
+	service := NewTaskService(repo, auth.NewPolicy(true), Quotas{Default: 1}, logger.Nop(), nil)
+	ctx := auth.WithWorkspace(context.Background(), "team-a")
+
+	// Все запросы стартуют разом: проверка квоты и запись не должны разойтись
+	start := make(chan struct{})
+	errs := make(chan error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := service.CreateTask(ctx, "Task", "", models.TaskPriorityLow, time.Time{})
+			errs <- err
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	exceeded := 0
+	for err := range errs {
+		if err != nil {
+			assert.True(t, errors.Is(err, apperror.ErrServiceQuotaExceeded), err)
+			exceeded++
+		}
+	}
+	assert.Equal(t, n-1, exceeded)
+	count, err := repo.InMemoryTaskRepository.Count(context.Background(), "team-a")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestTaskService_GetTask_ContextCancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	service := NewTaskService(mockRepo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	id := uuid.New()
+	mockRepo.EXPECT().GetByID(ctx, auth.DefaultWorkspace, id).Return(nil, context.Canceled)
+
+	_, err := service.GetTask(ctx, id)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/ports"
+)
+
+// exportPageSize — сколько задач выгрузка читает из хранилища за раз.
+const exportPageSize = 100
+
+// ExportTasks — выгрузка задач рабочего пространства, подходящих под фильтр, по одной в fn.
+// -- 1. Задачи читаются из хранилища страницами (ListPage): в памяти не больше страницы, а не все пространство.
+// -- 2. Порядок стабильный: по времени создания, затем по id — повторная выгрузка дает тот же файл.
+// -- 3. Выгрузка — не снимок: задачи, созданные во время нее, попадают в конец.
+// -- 4. Ошибка fn (например, клиент отключился) прекращает выгрузку и возвращается как есть.
+func (s *TaskService) ExportTasks(ctx context.Context, filter ports.TaskFilter, fn func(*models.Task) error) error {
+	if err := s.policy.Authorize(ctx, auth.ActionRead, nil); err != nil {
+		return err
+	}
+	workspace := auth.WorkspaceFrom(ctx)
+	var cursor ports.TaskCursor
+	for {
+		tasks, err := s.repository(ctx).ListPage(ctx, workspace, cursor, exportPageSize)
+		if err != nil {
+			return err
+		}
+		for _, t := range tasks {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if !filter.Match(t) {
+				continue
+			}
+			if err := fn(t); err != nil {
+				return err
+			}
+		}
+		if len(tasks) < exportPageSize {
+			return nil
+		}
+		cursor = ports.CursorOf(tasks[len(tasks)-1])
+	}
+}
+
+// ImportTask — загрузка задачи из выгрузки в рабочее пространство вызывающего.
+// -- 1. Задача проверяется и восстанавливается доменной моделью: id, временные метки и авторство сохраняются.
+// -- 2. Задача с таким id в пространстве уже есть — конфликт, существующая не перезаписывается.
+// -- 3. Неудаленная задача учитывается в квоте пространства, как и созданная.
+func (s *TaskService) ImportTask(ctx context.Context, snapshot models.TaskSnapshot) (*models.Task, error) {
+	if err := s.policy.Authorize(ctx, auth.ActionImport, nil); err != nil {
+		return nil, err
+	}
+	workspace := auth.WorkspaceFrom(ctx)
+	snapshot.Workspace = workspace
+	task, err := models.TaskFromSnapshot(snapshot)
+	if err != nil {
+		// В выгрузке статус — просто поле, поэтому и его ошибка — валидация, а не конфликт перехода.
+		return nil, apperror.ErrServiceValidation.Wrap(err).WithField(models.FieldOf(err), err.Error())
+	}
+	// Проверка id, квота и запись — одной транзакцией: параллельная загрузка той же задачи получает конфликт,
+	// а не перезаписывает первую.
+	err = s.atomically(ctx, func(ctx context.Context) error {
+		_, err := s.repository(ctx).GetByID(ctx, workspace, task.ID())
+		switch {
+		case err == nil:
+			return apperror.ErrServiceConflict.WithField("id", "task "+task.ID().String()+" already exists")
+		case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+			return err
+		}
+		if !task.IsDeleted() {
+			if err := s.checkQuota(ctx, workspace); err != nil {
+				return err
+			}
+		}
+		if err := s.repository(ctx).Save(ctx, task); err != nil {
+			return apperror.ErrRepoSaveFailed.Wrap(err)
+		}
+		afterCommit(ctx, func() { s.metrics.TaskSaved(task) })
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
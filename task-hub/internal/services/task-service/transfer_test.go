@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/ports"
+	inmemory "github.com/vagonaizer/workmate/task-hub/internal/repository/in-memory"
+	"github.com/vagonaizer/workmate/task-hub/pkg/logger"
+)
+
+func TestTaskService_ExportTasks(t *testing.T) {
+	service := NewTaskService(inmemory.NewInMemoryTaskRepository(), auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+	ctx := context.Background()
+	var ids []uuid.UUID
+	for _, title := range []string{"a", "b", "c"} {
+		task, err := service.CreateTask(ctx, title, "", models.TaskPriorityLow, time.Time{})
+		if !assert.NoError(t, err) {
+			return
+		}
+		ids = append(ids, task.ID())
+	}
+	assert.NoError(t, service.UpdatePriority(ctx, ids[1], models.TaskPriorityHigh))
+	assert.NoError(t, service.DeleteTask(ctx, ids[2]))
+
+	export := func(filter ports.TaskFilter) []string {
+		var titles []string
+		assert.NoError(t, service.ExportTasks(ctx, filter, func(task *models.Task) error {
+			titles = append(titles, task.Title())
+			return nil
+		}))
+		return titles
+	}
+
+	// Порядок — по времени создания; корзина только по запросу
+	assert.Equal(t, []string{"a", "b"}, export(ports.TaskFilter{}))
+	assert.Equal(t, []string{"a", "b", "c"}, export(ports.TaskFilter{IncludeDeleted: true}))
+	assert.Equal(t, []string{"c"}, export(ports.TaskFilter{Statuses: []models.TaskStatus{models.TaskStatusDeleted}}))
+	assert.Equal(t, []string{"b"}, export(ports.TaskFilter{Priorities: []models.TaskPriority{models.TaskPriorityHigh}}))
+
+	// Ошибка fn прекращает выгрузку
+	stop := errors.New("client gone")
+	calls := 0
+	err := service.ExportTasks(ctx, ports.TaskFilter{}, func(*models.Task) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+// pagedRepository — хранилище в памяти, считающее прочитанные выгрузкой страницы.
+type pagedRepository struct {
+	*inmemory.InMemoryTaskRepository
+	pages int
+}
+
+func (r *pagedRepository) ListPage(ctx context.Context, workspace string, after ports.TaskCursor, limit int) ([]*models.Task, error) {
+	r.pages++
+	return r.InMemoryTaskRepository.ListPage(ctx, workspace, after, limit)
+}
+
+func TestTaskService_ExportTasks_Pages(t *testing.T) {
+	repo := &pagedRepository{InMemoryTaskRepository: inmemory.NewInMemoryTaskRepository()}
+	service := NewTaskService(repo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+	ctx := context.Background()
+	var want []ports.TaskCursor
+	for i := 0; i < 2*exportPageSize+1; i++ {
+		task, err := service.CreateTask(ctx, "Task", "", models.TaskPriorityLow, time.Time{})
+		if !assert.NoError(t, err) {
+			return
+		}
+		want = append(want, ports.CursorOf(task))
+	}
+	slices.SortFunc(want, ports.TaskCursor.Compare)
+
+	// Три страницы: две полные и последняя из одной задачи, порядок сохраняется на стыках
+	var got []ports.TaskCursor
+	assert.NoError(t, service.ExportTasks(ctx, ports.TaskFilter{}, func(task *models.Task) error {
+		got = append(got, ports.CursorOf(task))
+		return nil
+	}))
+	assert.Equal(t, want, got)
+	assert.Equal(t, 3, repo.pages)
+}
+
+func TestTaskService_ImportTask(t *testing.T) {
+	metrics := &savedMetrics{}
+	service := NewTaskService(inmemory.NewInMemoryTaskRepository(), auth.NewPolicy(false), Quotas{Default: 1}, logger.Nop(), metrics)
+	admin := auth.WithWorkspace(auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "root", Roles: []string{auth.RoleAdmin}}), "acme")
+	created := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	snapshot := models.TaskSnapshot{
+		ID: uuid.New(), Title: "old", Status: models.TaskStatusPending, CreatedAt: created,
+		CreatedBy: "alice", Workspace: "other",
+	}
+
+	// 1. id, время и авторство сохраняются, пространство — вызывающего
+	task, err := service.ImportTask(admin, snapshot)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, snapshot.ID, task.ID())
+	assert.Equal(t, created, task.CreatedAt())
+	assert.Equal(t, "alice", task.CreatedBy())
+	assert.Equal(t, "acme", task.Workspace())
+	assert.Equal(t, 1, metrics.saved)
+	got, err := service.GetTask(admin, snapshot.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", got.Title())
+
+	// 2. Повтор — конфликт, невалидная задача — валидация с полем (в том числе статус)
+	_, err = service.ImportTask(admin, snapshot)
+	assert.True(t, errors.Is(err, apperror.ErrServiceConflict))
+	bad := snapshot
+	bad.ID, bad.Status = uuid.New(), "done"
+	_, err = service.ImportTask(admin, bad)
+	assert.True(t, errors.Is(err, apperror.ErrServiceValidation))
+	assert.True(t, errors.Is(err, models.ErrInvalidStatus))
+
+	// 3. Квота: неудаленная задача не помещается, задача в корзине — помещается
+	next := snapshot
+	next.ID = uuid.New()
+	_, err = service.ImportTask(admin, next)
+	assert.True(t, errors.Is(err, apperror.ErrServiceQuotaExceeded))
+	next.Status, next.PrevStatus, next.DeletedAt = models.TaskStatusDeleted, models.TaskStatusPending, created
+	_, err = service.ImportTask(admin, next)
+	assert.NoError(t, err)
+
+	// 4. Импорт только для admin
+	member := auth.WithWorkspace(auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "bob", Roles: []string{auth.RoleMember}}), "acme")
+	next.ID = uuid.New()
+	_, err = service.ImportTask(member, next)
+	assert.True(t, errors.Is(err, apperror.ErrServiceForbidden))
+}
+
+func TestTaskService_ImportTask_Concurrent(t *testing.T) {
+	const n = 10
+	repo := &barrierRepository{InMemoryTaskRepository: inmemory.NewInMemoryTaskRepository()}
+	repo.arrived.Add(n)
+	service := NewTaskService(repo, auth.NewPolicy(true), Quotas{}, logger.Nop(), nil)
+	ctx := auth.WithWorkspace(context.Background(), "acme")
+
+	// Одна и та же задача загружается параллельно с разным содержимым: сохраняется первая, остальные — конфликт
+	id := uuid.New()
+	start := make(chan struct{})
+	errs := make(chan error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, err := service.ImportTask(ctx, models.TaskSnapshot{
+				ID: id, Title: "copy " + strconv.Itoa(i), Status: models.TaskStatusPending, CreatedAt: time.Now(),
+			})
+			errs <- err
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	conflicts := 0
+	for err := range errs {
+		if err != nil {
+			assert.True(t, errors.Is(err, apperror.ErrServiceConflict), err)
+			conflicts++
+		}
+	}
+	assert.Equal(t, n-1, conflicts)
+}
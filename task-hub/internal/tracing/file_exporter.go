@@ -0,0 +1,216 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// FileExporter — экспортер спанов в файл в формате OTLP-JSON.
+// -- Каждая пачка спанов — одна строка с объектом ExportTraceServiceRequest
+// -- (как у file exporter в OpenTelemetry Collector), идентификаторы — hex.
+// -- Такой файл можно подсунуть коллектору (otlpjsonfile receiver) или открыть в Jaeger/Grafana.
+type FileExporter struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// Убеждаемся, что FileExporter реализует интерфейс SpanExporter.
+var _ sdktrace.SpanExporter = (*FileExporter)(nil)
+
+// NewFileExporter — открывает (или создает) файл на дозапись.
+func NewFileExporter(path string) (*FileExporter, error) {
+	if path == "" {
+		return nil, fmt.Errorf("tracing file exporter: empty path")
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("tracing file exporter: %w", err)
+	}
+	return &FileExporter{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+// ExportSpans — запись пачки спанов одной строкой.
+func (e *FileExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.file == nil {
+		return nil
+	}
+	return e.enc.Encode(toOTLP(spans))
+}
+
+// Shutdown — закрытие файла; повторный вызов ничего не делает.
+func (e *FileExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.file == nil {
+		return nil
+	}
+	err := e.file.Close()
+	e.file = nil
+	return err
+}
+
+// -- Структуры OTLP-JSON (подмножество, достаточное для трасс).
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+// otlpValue — AnyValue; int64 в OTLP-JSON передается строкой.
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// toOTLP — группировка спанов по ресурсу и instrumentation scope.
+func toOTLP(spans []sdktrace.ReadOnlySpan) otlpRequest {
+	type scopeKey struct{ name, version string }
+	var req otlpRequest
+	byResource := make(map[string]int)
+	byScope := make(map[string]map[scopeKey]int)
+
+	for _, s := range spans {
+		resKey := s.Resource().Encoded(attribute.DefaultEncoder())
+		ri, ok := byResource[resKey]
+		if !ok {
+			ri = len(req.ResourceSpans)
+			byResource[resKey] = ri
+			byScope[resKey] = make(map[scopeKey]int)
+			req.ResourceSpans = append(req.ResourceSpans, otlpResourceSpans{
+				Resource: otlpResource{Attributes: toKeyValues(s.Resource().Attributes())},
+			})
+		}
+		scope := s.InstrumentationScope()
+		sk := scopeKey{scope.Name, scope.Version}
+		si, ok := byScope[resKey][sk]
+		if !ok {
+			si = len(req.ResourceSpans[ri].ScopeSpans)
+			byScope[resKey][sk] = si
+			req.ResourceSpans[ri].ScopeSpans = append(req.ResourceSpans[ri].ScopeSpans, otlpScopeSpans{
+				Scope: otlpScope{Name: scope.Name, Version: scope.Version},
+			})
+		}
+		ss := &req.ResourceSpans[ri].ScopeSpans[si]
+		ss.Spans = append(ss.Spans, toSpan(s))
+	}
+	return req
+}
+
+func toSpan(s sdktrace.ReadOnlySpan) otlpSpan {
+	span := otlpSpan{
+		TraceID:           s.SpanContext().TraceID().String(),
+		SpanID:            s.SpanContext().SpanID().String(),
+		Name:              s.Name(),
+		Kind:              int(s.SpanKind()), // значения trace.SpanKind совпадают с OTLP
+		StartTimeUnixNano: strconv.FormatInt(s.StartTime().UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.EndTime().UnixNano(), 10),
+		Attributes:        toKeyValues(s.Attributes()),
+	}
+	if s.Parent().IsValid() {
+		span.ParentSpanID = s.Parent().SpanID().String()
+	}
+	for _, ev := range s.Events() {
+		span.Events = append(span.Events, otlpEvent{
+			TimeUnixNano: strconv.FormatInt(ev.Time.UnixNano(), 10),
+			Name:         ev.Name,
+			Attributes:   toKeyValues(ev.Attributes),
+		})
+	}
+	// В OTLP: 0 — unset, 1 — ok, 2 — error (в codes порядок другой).
+	switch s.Status().Code {
+	case codes.Ok:
+		span.Status = otlpStatus{Code: 1}
+	case codes.Error:
+		span.Status = otlpStatus{Code: 2, Message: s.Status().Description}
+	}
+	return span
+}
+
+func toKeyValues(attrs []attribute.KeyValue) []otlpKeyValue {
+	out := make([]otlpKeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		var v otlpValue
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			b := kv.Value.AsBool()
+			v.BoolValue = &b
+		case attribute.INT64:
+			i := strconv.FormatInt(kv.Value.AsInt64(), 10)
+			v.IntValue = &i
+		case attribute.FLOAT64:
+			f := kv.Value.AsFloat64()
+			v.DoubleValue = &f
+		default:
+			str := kv.Value.Emit()
+			v.StringValue = &str
+		}
+		out = append(out, otlpKeyValue{Key: string(kv.Key), Value: v})
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/ports"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedRepository — декоратор TaskRepository: клиентский спан на каждое обращение к хранилищу.
+type tracedRepository struct {
+	next ports.TaskRepository
+}
+
+// Убеждаемся, что tracedRepository реализует интерфейс TaskRepository.
+var _ ports.TaskRepository = (*tracedRepository)(nil)
+
+// WrapRepository — оборачивает репозиторий спанами.
+// -- Если репозиторий поддерживает транзакции, обертка тоже их поддерживает.
+func WrapRepository(next ports.TaskRepository) ports.TaskRepository {
+	if tx, ok := next.(ports.Transactor); ok {
+		return &tracedTxRepository{tracedRepository: tracedRepository{next: next}, tx: tx}
+	}
+	return &tracedRepository{next: next}
+}
+
+// tracedTxRepository — tracedRepository для хранилища с транзакциями:
+// спан на транзакцию целиком, обращения внутри нее — дочерние спаны.
+type tracedTxRepository struct {
+	tracedRepository
+	tx ports.Transactor
+}
+
+var _ ports.Transactor = (*tracedTxRepository)(nil)
+
+func (r *tracedTxRepository) InTx(ctx context.Context, fn func(ctx context.Context, tx ports.TaskRepository) error) error {
+	ctx, span := Tracer().Start(ctx, "TaskRepository.InTx", trace.WithSpanKind(trace.SpanKindClient))
+	err := r.tx.InTx(ctx, func(ctx context.Context, tx ports.TaskRepository) error {
+		return fn(ctx, &tracedRepository{next: tx})
+	})
+	end(span, err)
+	return err
+}
+
+func (r *tracedRepository) start(ctx context.Context, name, workspace string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, AttrWorkspace.String(workspace), attribute.String("db.operation.name", name))
+	return Tracer().Start(ctx, "TaskRepository."+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+func (r *tracedRepository) Save(ctx context.Context, task *models.Task) error {
+	ctx, span := r.start(ctx, "Save", task.Workspace(),
+		AttrTaskID.String(task.ID().String()),
+		AttrTaskStatus.String(string(task.Status())),
+	)
+	err := r.next.Save(ctx, task)
+	end(span, err)
+	return err
+}
+
+func (r *tracedRepository) GetByID(ctx context.Context, workspace string, id uuid.UUID) (*models.Task, error) {
+	ctx, span := r.start(ctx, "GetByID", workspace, AttrTaskID.String(id.String()))
+	task, err := r.next.GetByID(ctx, workspace, id)
+	if err == nil {
+		span.SetAttributes(AttrTaskStatus.String(string(task.Status())))
+	}
+	end(span, err)
+	return task, err
+}
+
+func (r *tracedRepository) Delete(ctx context.Context, workspace string, id uuid.UUID) error {
+	ctx, span := r.start(ctx, "Delete", workspace, AttrTaskID.String(id.String()))
+	err := r.next.Delete(ctx, workspace, id)
+	end(span, err)
+	return err
+}
+
+func (r *tracedRepository) List(ctx context.Context, workspace string) ([]*models.Task, error) {
+	ctx, span := r.start(ctx, "List", workspace)
+	tasks, err := r.next.List(ctx, workspace)
+	span.SetAttributes(attribute.Int("list.count", len(tasks)))
+	end(span, err)
+	return tasks, err
+}
+
+func (r *tracedRepository) Count(ctx context.Context, workspace string) (int, error) {
+	ctx, span := r.start(ctx, "Count", workspace)
+	n, err := r.next.Count(ctx, workspace)
+	end(span, err)
+	return n, err
+}
+
+func (r *tracedRepository) ListPage(ctx context.Context, workspace string, after ports.TaskCursor, limit int) ([]*models.Task, error) {
+	ctx, span := r.start(ctx, "ListPage", workspace, attribute.Int("list.limit", limit))
+	tasks, err := r.next.ListPage(ctx, workspace, after, limit)
+	span.SetAttributes(attribute.Int("list.count", len(tasks)))
+	end(span, err)
+	return tasks, err
+}
@@ -0,0 +1,230 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/ports"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedService — декоратор TaskService: спан на каждый вызов с id задачи,
+// рабочим пространством и статусом (целевым для переходов, фактическим для чтения/создания).
+type tracedService struct {
+	next ports.TaskService
+}
+
+// Убеждаемся, что tracedService реализует интерфейс TaskService.
+var _ ports.TaskService = (*tracedService)(nil)
+
+// WrapService — оборачивает сервис спанами.
+func WrapService(next ports.TaskService) ports.TaskService {
+	return &tracedService{next: next}
+}
+
+func (s *tracedService) start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, AttrWorkspace.String(auth.WorkspaceFrom(ctx)))
+	return Tracer().Start(ctx, "TaskService."+name, trace.WithAttributes(attrs...))
+}
+
+// transition — вызов метода, меняющего задачу; to — целевой статус (пусто, если статус не меняется).
+func (s *tracedService) transition(ctx context.Context, name string, id uuid.UUID, to models.TaskStatus, call func(context.Context) error) error {
+	attrs := []attribute.KeyValue{AttrTaskID.String(id.String())}
+	if to != "" {
+		attrs = append(attrs, AttrTaskStatus.String(string(to)))
+	}
+	ctx, span := s.start(ctx, name, attrs...)
+	err := call(ctx)
+	end(span, err)
+	return err
+}
+
+func (s *tracedService) CreateTask(ctx context.Context, title, description string, priority models.TaskPriority, deadline time.Time) (*models.Task, error) {
+	ctx, span := s.start(ctx, "CreateTask", attribute.String("task.priority", string(priority)))
+	task, err := s.next.CreateTask(ctx, title, description, priority, deadline)
+	if err == nil {
+		span.SetAttributes(AttrTaskID.String(task.ID().String()), AttrTaskStatus.String(string(task.Status())))
+	}
+	end(span, err)
+	return task, err
+}
+
+func (s *tracedService) GetTask(ctx context.Context, id uuid.UUID) (*models.Task, error) {
+	ctx, span := s.start(ctx, "GetTask", AttrTaskID.String(id.String()))
+	task, err := s.next.GetTask(ctx, id)
+	if err == nil {
+		span.SetAttributes(AttrTaskStatus.String(string(task.Status())))
+	}
+	end(span, err)
+	return task, err
+}
+
+func (s *tracedService) DeleteTask(ctx context.Context, id uuid.UUID) error {
+	return s.transition(ctx, "DeleteTask", id, models.TaskStatusDeleted, func(ctx context.Context) error {
+		return s.next.DeleteTask(ctx, id)
+	})
+}
+
+func (s *tracedService) RestoreTask(ctx context.Context, id uuid.UUID) error {
+	return s.transition(ctx, "RestoreTask", id, "", func(ctx context.Context) error {
+		return s.next.RestoreTask(ctx, id)
+	})
+}
+
+func (s *tracedService) PurgeDeleted(ctx context.Context, retention time.Duration) (int, error) {
+	ctx, span := s.start(ctx, "PurgeDeleted", attribute.String("purge.retention", retention.String()))
+	purged, err := s.next.PurgeDeleted(ctx, retention)
+	span.SetAttributes(attribute.Int("purge.count", purged))
+	end(span, err)
+	return purged, err
+}
+
+func (s *tracedService) CheckpointRunning(ctx context.Context) (int, error) {
+	ctx, span := s.start(ctx, "CheckpointRunning")
+	checkpointed, err := s.next.CheckpointRunning(ctx)
+	span.SetAttributes(attribute.Int("checkpoint.count", checkpointed))
+	end(span, err)
+	return checkpointed, err
+}
+
+func (s *tracedService) ListTasks(ctx context.Context, includeDeleted bool) ([]*models.Task, error) {
+	ctx, span := s.start(ctx, "ListTasks", attribute.Bool("list.include_deleted", includeDeleted))
+	tasks, err := s.next.ListTasks(ctx, includeDeleted)
+	span.SetAttributes(attribute.Int("list.count", len(tasks)))
+	end(span, err)
+	return tasks, err
+}
+
+func (s *tracedService) StartTask(ctx context.Context, id uuid.UUID) error {
+	return s.transition(ctx, "StartTask", id, models.TaskStatusInProgress, func(ctx context.Context) error {
+		return s.next.StartTask(ctx, id)
+	})
+}
+
+func (s *tracedService) PauseTask(ctx context.Context, id uuid.UUID) error {
+	return s.transition(ctx, "PauseTask", id, models.TaskStatusPaused, func(ctx context.Context) error {
+		return s.next.PauseTask(ctx, id)
+	})
+}
+
+func (s *tracedService) ResumeTask(ctx context.Context, id uuid.UUID) error {
+	return s.transition(ctx, "ResumeTask", id, models.TaskStatusInProgress, func(ctx context.Context) error {
+		return s.next.ResumeTask(ctx, id)
+	})
+}
+
+func (s *tracedService) CompleteTask(ctx context.Context, id uuid.UUID) error {
+	return s.transition(ctx, "CompleteTask", id, models.TaskStatusCompleted, func(ctx context.Context) error {
+		return s.next.CompleteTask(ctx, id)
+	})
+}
+
+func (s *tracedService) CancelTask(ctx context.Context, id uuid.UUID) error {
+	return s.transition(ctx, "CancelTask", id, models.TaskStatusCancelled, func(ctx context.Context) error {
+		return s.next.CancelTask(ctx, id)
+	})
+}
+
+func (s *tracedService) FailTask(ctx context.Context, id uuid.UUID) error {
+	return s.transition(ctx, "FailTask", id, models.TaskStatusFailed, func(ctx context.Context) error {
+		return s.next.FailTask(ctx, id)
+	})
+}
+
+func (s *tracedService) ReopenTask(ctx context.Context, id uuid.UUID, reason string) error {
+	return s.transition(ctx, "ReopenTask", id, models.TaskStatusPending, func(ctx context.Context) error {
+		return s.next.ReopenTask(ctx, id, reason)
+	})
+}
+
+func (s *tracedService) RetryTask(ctx context.Context, id uuid.UUID, reason string) error {
+	return s.transition(ctx, "RetryTask", id, models.TaskStatusPending, func(ctx context.Context) error {
+		return s.next.RetryTask(ctx, id, reason)
+	})
+}
+
+func (s *tracedService) UpdateTitle(ctx context.Context, id uuid.UUID, title string) error {
+	return s.transition(ctx, "UpdateTitle", id, "", func(ctx context.Context) error {
+		return s.next.UpdateTitle(ctx, id, title)
+	})
+}
+
+func (s *tracedService) UpdateDescription(ctx context.Context, id uuid.UUID, description string) error {
+	return s.transition(ctx, "UpdateDescription", id, "", func(ctx context.Context) error {
+		return s.next.UpdateDescription(ctx, id, description)
+	})
+}
+
+func (s *tracedService) UpdatePriority(ctx context.Context, id uuid.UUID, priority models.TaskPriority) error {
+	return s.transition(ctx, "UpdatePriority", id, "", func(ctx context.Context) error {
+		return s.next.UpdatePriority(ctx, id, priority)
+	})
+}
+
+func (s *tracedService) UpdateDeadline(ctx context.Context, id uuid.UUID, deadline time.Time) error {
+	return s.transition(ctx, "UpdateDeadline", id, "", func(ctx context.Context) error {
+		return s.next.UpdateDeadline(ctx, id, deadline)
+	})
+}
+
+func (s *tracedService) AssignTask(ctx context.Context, id uuid.UUID, assignee string) error {
+	return s.transition(ctx, "AssignTask", id, "", func(ctx context.Context) error {
+		return s.next.AssignTask(ctx, id, assignee)
+	})
+}
+
+func (s *tracedService) ChangeStatus(ctx context.Context, id uuid.UUID, status models.TaskStatus, reason string) error {
+	return s.transition(ctx, "ChangeStatus", id, status, func(ctx context.Context) error {
+		return s.next.ChangeStatus(ctx, id, status, reason)
+	})
+}
+
+func (s *tracedService) CreateTasks(ctx context.Context, drafts []ports.TaskDraft, atomic bool) ([]ports.BulkResult, error) {
+	return s.bulk(ctx, "CreateTasks", len(drafts), atomic, func(ctx context.Context) ([]ports.BulkResult, error) {
+		return s.next.CreateTasks(ctx, drafts, atomic)
+	})
+}
+
+func (s *tracedService) ChangeStatuses(ctx context.Context, changes []ports.StatusChange, atomic bool) ([]ports.BulkResult, error) {
+	return s.bulk(ctx, "ChangeStatuses", len(changes), atomic, func(ctx context.Context) ([]ports.BulkResult, error) {
+		return s.next.ChangeStatuses(ctx, changes, atomic)
+	})
+}
+
+// bulk — пакетная операция: размер пакета, режим и число элементов с ошибкой.
+func (s *tracedService) bulk(ctx context.Context, name string, size int, atomic bool, call func(context.Context) ([]ports.BulkResult, error)) ([]ports.BulkResult, error) {
+	ctx, span := s.start(ctx, name, attribute.Int("bulk.size", size), attribute.Bool("bulk.atomic", atomic))
+	results, err := call(ctx)
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	span.SetAttributes(attribute.Int("bulk.failed", failed))
+	end(span, err)
+	return results, err
+}
+
+func (s *tracedService) ExportTasks(ctx context.Context, filter ports.TaskFilter, fn func(*models.Task) error) error {
+	ctx, span := s.start(ctx, "ExportTasks", attribute.Bool("list.include_deleted", filter.IncludeDeleted))
+	count := 0
+	err := s.next.ExportTasks(ctx, filter, func(t *models.Task) error {
+		count++
+		return fn(t)
+	})
+	span.SetAttributes(attribute.Int("list.count", count))
+	end(span, err)
+	return err
+}
+
+func (s *tracedService) ImportTask(ctx context.Context, snapshot models.TaskSnapshot) (*models.Task, error) {
+	ctx, span := s.start(ctx, "ImportTask", AttrTaskID.String(snapshot.ID.String()), AttrTaskStatus.String(string(snapshot.Status)))
+	task, err := s.next.ImportTask(ctx, snapshot)
+	end(span, err)
+	return task, err
+}
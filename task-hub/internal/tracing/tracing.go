@@ -0,0 +1,128 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName — имя трейсера (instrumentation scope) для спанов task-hub.
+const instrumentationName = "github.com/vagonaizer/workmate/task-hub"
+
+// Ключи атрибутов спанов.
+const (
+	AttrTaskID     = attribute.Key("task.id")
+	AttrTaskStatus = attribute.Key("task.status")
+	AttrWorkspace  = attribute.Key("task.workspace")
+	AttrRequestID  = attribute.Key("request.id")
+)
+
+// Exporter — куда отправляются спаны.
+type Exporter string
+
+const (
+	ExporterStdout Exporter = "stdout" // читаемый JSON в stdout, для локальной отладки
+	ExporterFile   Exporter = "file"   // OTLP-JSON в файл, по строке на пачку спанов
+	ExporterOTLP   Exporter = "otlp"   // OTLP/HTTP на коллектор
+)
+
+// Options — настройки трассировки.
+type Options struct {
+	ServiceName    string
+	ServiceVersion string
+	Exporter       Exporter
+	File           string  // для ExporterFile
+	Endpoint       string  // host:port для ExporterOTLP
+	Insecure       bool    // OTLP без TLS
+	SampleRatio    float64 // доля трассируемых корневых спанов, 0..1
+}
+
+// Provider — настроенный провайдер трассировки; нужен, чтобы дослать спаны при остановке.
+type Provider struct {
+	tp *sdktrace.TracerProvider
+}
+
+// Setup — создание провайдера и регистрация его глобально (вместе с W3C trace context).
+// -- Без вызова Setup глобальный провайдер no-op: спаны создаются, но никуда не пишутся.
+func Setup(ctx context.Context, opts Options) (*Provider, error) {
+	exporter, err := newExporter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(opts.ServiceName),
+		semconv.ServiceVersion(opts.ServiceVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("tracing resource: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return &Provider{tp: tp}, nil
+}
+
+func newExporter(ctx context.Context, opts Options) (sdktrace.SpanExporter, error) {
+	switch Exporter(strings.ToLower(string(opts.Exporter))) {
+	case ExporterStdout, "":
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterFile:
+		return NewFileExporter(opts.File)
+	case ExporterOTLP:
+		clientOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(opts.Endpoint)}
+		if opts.Insecure {
+			clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, clientOpts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", opts.Exporter)
+	}
+}
+
+// Shutdown — досылает накопленные спаны и закрывает экспортер. Безопасен для nil.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+	return p.tp.Shutdown(ctx)
+}
+
+// Tracer — трейсер task-hub из глобального провайдера.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// IDs — идентификаторы трассы и спана из контекста (пустые, если спана нет) для корреляции с логами.
+func IDs(ctx context.Context) (traceID, spanID string) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return "", ""
+	}
+	return sc.TraceID().String(), sc.SpanID().String()
+}
+
+// end — завершение спана с фиксацией ошибки.
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,167 @@
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/ports"
+	inmemory "github.com/vagonaizer/workmate/task-hub/internal/repository/in-memory"
+	service "github.com/vagonaizer/workmate/task-hub/internal/services/task-service"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// useRecorder — подменяет глобальный провайдер на запоминающий спаны в памяти.
+func useRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return recorder
+}
+
+func attrs(span sdktrace.ReadOnlySpan) map[attribute.Key]string {
+	out := make(map[attribute.Key]string)
+	for _, kv := range span.Attributes() {
+		out[kv.Key] = kv.Value.Emit()
+	}
+	return out
+}
+
+func TestWrapService_SpansWithTaskAttributes(t *testing.T) {
+	recorder := useRecorder(t)
+	repo := WrapRepository(inmemory.NewInMemoryTaskRepository())
+	svc := WrapService(service.NewTaskService(repo, auth.NewPolicy(true), service.Quotas{}, nil, nil))
+	ctx := auth.WithWorkspace(context.Background(), "team-a")
+
+	task, err := svc.CreateTask(ctx, "traced", "", "high", zeroTime)
+	assert.NoError(t, err)
+	assert.NoError(t, svc.StartTask(ctx, task.ID()))
+
+	spans := recorder.Ended()
+	byName := make(map[string][]sdktrace.ReadOnlySpan)
+	for _, s := range spans {
+		byName[s.Name()] = append(byName[s.Name()], s)
+	}
+
+	create := byName["TaskService.CreateTask"]
+	if !assert.Len(t, create, 1) {
+		return
+	}
+	assert.Equal(t, task.ID().String(), attrs(create[0])[AttrTaskID])
+	assert.Equal(t, "pending", attrs(create[0])[AttrTaskStatus])
+	assert.Equal(t, "team-a", attrs(create[0])[AttrWorkspace])
+
+	start := byName["TaskService.StartTask"]
+	if !assert.Len(t, start, 1) {
+		return
+	}
+	assert.Equal(t, "in_progress", attrs(start[0])[AttrTaskStatus])
+
+	// Спаны репозитория — дочерние к спану сервиса
+	var save sdktrace.ReadOnlySpan
+	for _, s := range byName["TaskRepository.Save"] {
+		if attrs(s)[AttrTaskStatus] == "in_progress" {
+			save = s
+		}
+	}
+	if !assert.NotNil(t, save) {
+		return
+	}
+	assert.Equal(t, start[0].SpanContext().SpanID(), save.Parent().SpanID())
+}
+
+func TestWrapService_ErrorStatus(t *testing.T) {
+	recorder := useRecorder(t)
+	svc := WrapService(service.NewTaskService(WrapRepository(inmemory.NewInMemoryTaskRepository()), auth.NewPolicy(true), service.Quotas{}, nil, nil))
+
+	_, err := svc.CreateTask(context.Background(), "", "", "high", zeroTime)
+	assert.Error(t, err)
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 1) {
+		return
+	}
+	assert.Equal(t, "Error", spans[0].Status().Code.String())
+}
+
+func TestWrapRepository_Transactions(t *testing.T) {
+	recorder := useRecorder(t)
+	repo := WrapRepository(inmemory.NewInMemoryTaskRepository())
+	_, ok := repo.(ports.Transactor)
+	if !assert.True(t, ok) {
+		return
+	}
+	svc := WrapService(service.NewTaskService(repo, auth.NewPolicy(true), service.Quotas{}, nil, nil))
+
+	results, err := svc.CreateTasks(context.Background(), []ports.TaskDraft{{Title: "a"}, {Title: "b"}}, true)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	byName := make(map[string][]sdktrace.ReadOnlySpan)
+	for _, s := range recorder.Ended() {
+		byName[s.Name()] = append(byName[s.Name()], s)
+	}
+	bulk, tx := byName["TaskService.CreateTasks"], byName["TaskRepository.InTx"]
+	if !assert.Len(t, bulk, 1) || !assert.Len(t, tx, 1) || !assert.Len(t, byName["TaskRepository.Save"], 2) {
+		return
+	}
+	assert.Equal(t, "2", attrs(bulk[0])["bulk.size"])
+	assert.Equal(t, bulk[0].SpanContext().SpanID(), tx[0].Parent().SpanID())
+	// Обращения внутри транзакции — дочерние к ее спану
+	for _, save := range byName["TaskRepository.Save"] {
+		assert.Equal(t, tx[0].SpanContext().SpanID(), save.Parent().SpanID())
+	}
+}
+
+func TestFileExporter_OTLPJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	exporter, err := NewFileExporter(path)
+	assert.NoError(t, err)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	_, child := tp.Tracer("test").Start(ctx, "child")
+	child.SetAttributes(AttrTaskID.String("42"), attribute.Int("list.count", 3))
+	child.End()
+	parent.End()
+	assert.NoError(t, tp.Shutdown(context.Background()))
+
+	f, err := os.Open(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer f.Close()
+
+	var spans []otlpSpan
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var req otlpRequest
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &req))
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				assert.Equal(t, "test", ss.Scope.Name)
+				spans = append(spans, ss.Spans...)
+			}
+		}
+	}
+	if !assert.Len(t, spans, 2) {
+		return
+	}
+	childSpan, parentSpan := spans[0], spans[1]
+	assert.Equal(t, "child", childSpan.Name)
+	assert.Len(t, childSpan.TraceID, 32) // hex, а не base64
+	assert.Equal(t, parentSpan.SpanID, childSpan.ParentSpanID)
+	assert.Equal(t, "42", *childSpan.Attributes[0].Value.StringValue)
+	assert.Equal(t, "3", *childSpan.Attributes[1].Value.IntValue)
+}
+
+var zeroTime time.Time
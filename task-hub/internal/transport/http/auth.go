@@ -0,0 +1,173 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
+	"github.com/vagonaizer/workmate/task-hub/pkg/logger"
+)
+
+const (
+	headerAPIKey    = "X-API-Key"      // заголовок с API-ключом
+	headerWorkspace = "X-Workspace-ID" // заголовок с рабочим пространством
+)
+
+// AuthHandler — аутентификация запросов и управление API-ключами.
+// -- Если аутентификация выключена в конфиге, middleware пропускают все запросы.
+type AuthHandler struct {
+	keys    *auth.APIKeyStore
+	jwt     *auth.JWTVerifier // nil, если bearer-токены не настроены
+	enabled bool
+	logger  *logger.Logger
+}
+
+func NewAuthHandler(keys *auth.APIKeyStore, jwt *auth.JWTVerifier, enabled bool, logger *logger.Logger) *AuthHandler {
+	return &AuthHandler{keys: keys, jwt: jwt, enabled: enabled, logger: logger}
+}
+
+// Authenticate — проверяет учетные данные и кладет вызывающего в контекст запроса.
+// -- 1. Authorization: Bearer <jwt>, если настроены токены.
+// -- 2. Иначе X-API-Key.
+func (a *AuthHandler) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !a.enabled {
+			c.Next()
+			return
+		}
+		principal, err := a.authenticate(c)
+		if err != nil {
+			_ = c.Error(apperror.ErrTransportUnauthorized.Wrap(err))
+			c.Abort()
+			return
+		}
+		c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// ResolveWorkspace — определяет рабочее пространство запроса.
+// -- 1. Если ключ/токен привязан к пространству — используется оно, чужой X-Workspace-ID запрещен.
+// -- 2. Иначе заголовок X-Workspace-ID, но только для admin или при выключенной аутентификации:
+// непривязанный ключ или токен без claim пространства не может выбрать чужое пространство.
+// -- 3. Иначе — пространство по умолчанию.
+func (a *AuthHandler) ResolveWorkspace() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		workspace := c.GetHeader(headerWorkspace)
+		if principal, ok := auth.PrincipalFrom(ctx); ok {
+			switch {
+			case principal.Workspace != "":
+				if workspace != "" && workspace != principal.Workspace {
+					_ = c.Error(apperror.ErrTransportForbidden.WithField("workspace", "credentials are bound to another workspace"))
+					c.Abort()
+					return
+				}
+				workspace = principal.Workspace
+			case principal.Role() != auth.RoleAdmin && workspace != "" && workspace != auth.DefaultWorkspace:
+				_ = c.Error(apperror.ErrTransportForbidden.WithField("workspace", "credentials are not bound to a workspace, only admin may choose one"))
+				c.Abort()
+				return
+			}
+		}
+		c.Request = c.Request.WithContext(auth.WithWorkspace(ctx, workspace))
+		c.Next()
+	}
+}
+
+func (a *AuthHandler) authenticate(c *gin.Context) (*auth.Principal, error) {
+	if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok && a.jwt != nil {
+		return a.jwt.Verify(strings.TrimSpace(token))
+	}
+	return a.keys.Authenticate(c.GetHeader(headerAPIKey))
+}
+
+// Require — проверяет, что у вызывающего есть нужный скоуп.
+func (a *AuthHandler) Require(scope auth.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !a.enabled {
+			c.Next()
+			return
+		}
+		principal, ok := auth.PrincipalFrom(c.Request.Context())
+		if !ok {
+			_ = c.Error(apperror.ErrTransportUnauthorized)
+			c.Abort()
+			return
+		}
+		if !principal.HasScope(scope) {
+			_ = c.Error(apperror.ErrTransportForbidden.WithField("scope", string(scope)+" required"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// @@route POST /api/v1/admin/keys
+// @@desc  Создать API-ключ (ключ возвращается один раз)
+// @@accept json
+// @@success 201 CreateAPIKeyResponseV1
+// @@error 400 Ошибка разбора запроса
+// @@error 409 key already exists
+// @@error 422 unknown scope
+func (a *AuthHandler) CreateAPIKey(c *gin.Context) {
+	var req CreateAPIKeyRequestV1
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(badRequest(err))
+		return
+	}
+	scopes := make([]auth.Scope, 0, len(req.Scopes))
+	for _, s := range req.Scopes {
+		scope, err := auth.ParseScope(s)
+		if err != nil {
+			_ = c.Error(apperror.ErrServiceValidation.Wrap(err).WithField("scopes", err.Error()))
+			return
+		}
+		scopes = append(scopes, scope)
+	}
+	raw, err := a.keys.Generate(req.Name, scopes, req.Workspace)
+	if err != nil {
+		if errors.Is(err, auth.ErrKeyExists) {
+			_ = c.Error(apperror.ErrServiceConflict.Wrap(err))
+			return
+		}
+		_ = c.Error(apperror.ErrServiceValidation.Wrap(err))
+		return
+	}
+	logger.FromContext(c.Request.Context(), a.logger).Info("Создан API-ключ %s", req.Name)
+	c.JSON(http.StatusCreated, CreateAPIKeyResponseV1{Name: req.Name, Key: raw, Scopes: req.Scopes, Workspace: req.Workspace})
+}
+
+// @@route GET /api/v1/admin/keys
+// @@desc  Список API-ключей (без самих ключей)
+// @@success 200 APIKeyListResponseV1
+func (a *AuthHandler) ListAPIKeys(c *gin.Context) {
+	keys := a.keys.List()
+	resp := make([]APIKeyResponseV1, 0, len(keys))
+	for _, k := range keys {
+		scopes := make([]string, 0, len(k.Scopes))
+		for _, s := range k.Scopes {
+			scopes = append(scopes, string(s))
+		}
+		resp = append(resp, APIKeyResponseV1{Name: k.Name, Scopes: scopes, Workspace: k.Workspace})
+	}
+	c.JSON(http.StatusOK, APIKeyListResponseV1{Keys: resp})
+}
+
+// @@route DELETE /api/v1/admin/keys/:name
+// @@desc  Отозвать API-ключ
+// @@success 204
+// @@error 404 not found
+func (a *AuthHandler) RevokeAPIKey(c *gin.Context) {
+	name := c.Param("name")
+	if err := a.keys.Revoke(name); err != nil {
+		_ = c.Error(apperror.ErrTransportNotFound.Wrap(err))
+		return
+	}
+	logger.FromContext(c.Request.Context(), a.logger).Info("Отозван API-ключ %s", name)
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/ports"
+)
+
+// @@route POST /api/v1/tasks/bulk
+// @@desc  Создать несколько задач
+// @@accept json
+// @@success 201 BulkResponseV1
+// @@error 207 BulkResponseV1 Часть задач не создана, ошибки — в results
+// @@error 400 Ошибка разбора запроса, пустой пакет или больше 100 задач
+// @@error 422 BulkResponseV1 atomic: ошибка хотя бы в одной задаче, не создано ничего
+// @@error 501 atomic: хранилище не поддерживает транзакции
+func (h *Handler) CreateTasksBulk(c *gin.Context) {
+	var req BulkCreateTasksRequestV1
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(badRequest(err))
+		return
+	}
+	drafts := make([]ports.TaskDraft, len(req.Tasks))
+	for i, t := range req.Tasks {
+		drafts[i] = ports.TaskDraft{Title: t.Title, Description: t.Description, Priority: t.Priority}
+		if t.Deadline != nil {
+			drafts[i].Deadline = *t.Deadline
+		}
+	}
+	h.log(c).Info("Пакетное создание задач: " + strconv.Itoa(len(drafts)))
+	results, err := h.taskService.CreateTasks(c.Request.Context(), drafts, req.Atomic)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	resp := h.bulkResponse(c, results, req.Atomic, http.StatusCreated)
+	for _, r := range resp.Results {
+		if r.Task == nil {
+			continue
+		}
+		// Как и одиночное создание, сохраняем задачу в файл
+		if err := saveTaskToFile(*r.Task); err != nil {
+			h.log(c).Error("Ошибка сохранения задачи в файл: %v", err)
+		}
+	}
+	c.JSON(bulkStatus(resp, http.StatusCreated), resp)
+}
+
+// @@route POST /api/v1/tasks/bulk/status
+// @@desc  Изменить статус нескольких задач
+// @@accept json
+// @@success 200 BulkResponseV1
+// @@error 207 BulkResponseV1 Часть переходов не выполнена, ошибки — в results
+// @@error 400 Ошибка разбора запроса, пустой пакет или больше 100 задач
+// @@error 422 BulkResponseV1 atomic: ошибка хотя бы в одном переходе, не изменено ничего
+// @@error 501 atomic: хранилище не поддерживает транзакции
+// -- Элементы с невалидным id или статусом получают 400 в results и не отправляются в сервис;
+// -- в атомарном режиме это тоже отменяет весь пакет.
+func (h *Handler) UpdateTaskStatusBulk(c *gin.Context) {
+	var req BulkUpdateStatusRequestV1
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(badRequest(err))
+		return
+	}
+	// Невалидные элементы отсекаются до сервиса, остальные передаются с запоминанием исходного индекса.
+	invalid := make(map[int]error)
+	changes := make([]ports.StatusChange, 0, len(req.Items))
+	indexes := make([]int, 0, len(req.Items))
+	for i, item := range req.Items {
+		id, err := uuid.Parse(item.ID)
+		if err != nil {
+			invalid[i] = apperror.New(apperror.ErrTransportBadRequest.Code, "invalid id")
+			continue
+		}
+		if !targetStatuses[item.Status] {
+			invalid[i] = apperror.New(apperror.ErrTransportBadRequest.Code, "invalid status")
+			continue
+		}
+		changes = append(changes, ports.StatusChange{ID: id, Status: item.Status, Reason: item.Reason})
+		indexes = append(indexes, i)
+	}
+	h.log(c).Info("Пакетная смена статусов: " + strconv.Itoa(len(req.Items)))
+
+	results := make([]ports.BulkResult, len(req.Items))
+	for i, err := range invalid {
+		results[i].Err = err
+	}
+	switch {
+	case len(changes) == 0:
+		// Все элементы невалидны — в сервис передавать нечего.
+	case req.Atomic && len(invalid) > 0:
+		// Пакет все равно не будет применен: в сервис не идем, корректным элементам — 424.
+		for _, i := range indexes {
+			results[i].Err = apperror.ErrServiceBatchAborted
+		}
+	default:
+		applied, err := h.taskService.ChangeStatuses(c.Request.Context(), changes, req.Atomic)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		for j, r := range applied {
+			results[indexes[j]] = r
+		}
+	}
+	resp := h.bulkResponse(c, results, req.Atomic, http.StatusOK)
+	c.JSON(bulkStatus(resp, http.StatusOK), resp)
+}
+
+// bulkResponse — результаты сервиса в DTO; ошибки элементов — в том же виде, что и у одиночных запросов.
+func (h *Handler) bulkResponse(c *gin.Context, results []ports.BulkResult, atomic bool, success int) BulkResponseV1 {
+	resp := BulkResponseV1{Results: make([]BulkItemResultV1, len(results)), Atomic: atomic}
+	for i, r := range results {
+		item := BulkItemResultV1{Index: i, Status: success}
+		if r.Err != nil {
+			status, body := toErrorResponse(r.Err)
+			body.Error.RequestID = requestID(c)
+			item.Status, item.Error = status, &body.Error
+			resp.Failed++
+		} else {
+			task := toTaskResponseV1(r.Task)
+			item.Task = &task
+			resp.Succeeded++
+		}
+		resp.Results[i] = item
+	}
+	return resp
+}
+
+// bulkStatus — HTTP-статус пакетного ответа.
+// -- 1. Все элементы успешны — как у одиночной операции.
+// -- 2. Атомарный пакет с ошибками — 422: не применено ничего.
+// -- 3. Иначе — 207 Multi-Status: результат у каждого элемента свой.
+func bulkStatus(resp BulkResponseV1, success int) int {
+	switch {
+	case resp.Failed == 0:
+		return success
+	case resp.Atomic:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusMultiStatus
+	}
+}
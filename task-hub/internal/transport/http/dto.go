@@ -1,32 +1,36 @@
 package http
 
-import (
-	"time"
+// Общие для всех версий API DTO: формат ошибок и служебные эндпоинты.
 
-	"github.com/google/uuid"
-	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
-)
+// ErrorResponse — единый формат ответа с ошибкой.
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody — описание ошибки: машиночитаемый код, сообщение, подробности и id запроса.
+type ErrorBody struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Details   any    `json:"details,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
 
-type CreateTaskRequest struct {
-	Title       string              `json:"title" binding:"required"`
-	Description string              `json:"description"`
-	Priority    models.TaskPriority `json:"priority"`
-	Deadline    *time.Time          `json:"deadline,omitempty"`
+// HealthResponse — ответ /healthz.
+type HealthResponse struct {
+	Status string `json:"status"`
 }
 
-type TaskResponse struct {
-	ID          uuid.UUID           `json:"id"`
-	Title       string              `json:"title"`
-	Description string              `json:"description"`
-	Status      models.TaskStatus   `json:"status"`
-	Priority    models.TaskPriority `json:"priority"`
-	CreatedAt   time.Time           `json:"created_at"`
-	UpdatedAt   time.Time           `json:"updated_at"`
-	CompletedAt *time.Time          `json:"completed_at,omitempty"`
-	Duration    int64               `json:"duration_seconds"`
-	Deadline    *time.Time          `json:"deadline,omitempty"`
+// ReadinessResponse — ответ /readyz: общий статус и результат каждой проверки.
+type ReadinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
 }
 
-type TaskListResponse struct {
-	Tasks []TaskResponse `json:"tasks"`
+// VersionResponse — ответ /version.
+type VersionResponse struct {
+	AppName   string `json:"app_name"`
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
 }
@@ -0,0 +1,174 @@
+package http
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+)
+
+// DTO API v1 (/api/v1 и алиас /api).
+// -- Это контракт с клиентами: поля не удаляются, не переименовываются и не меняют тип.
+// -- Допустимо только добавление необязательных полей; все остальное — новые типы *V2 и маршруты /api/v2.
+
+type CreateTaskRequestV1 struct {
+	Title       string              `json:"title" binding:"required"`
+	Description string              `json:"description"`
+	Priority    models.TaskPriority `json:"priority"`
+	Deadline    *time.Time          `json:"deadline,omitempty"`
+}
+
+// UpdateTaskStatusRequestV1 — смена статуса.
+// -- Статус "pending" переоткрывает завершенную/отмененную задачу или перезапускает упавшую, reason необязателен.
+type UpdateTaskStatusRequestV1 struct {
+	Status models.TaskStatus `json:"status" binding:"required"`
+	Reason string            `json:"reason"`
+}
+
+type UpdateTaskTitleRequestV1 struct {
+	Title string `json:"title" binding:"required"`
+}
+
+type UpdateTaskDescriptionRequestV1 struct {
+	Description string `json:"description"`
+}
+
+// UpdateTaskAssigneeRequestV1 — назначение исполнителя, пустая строка снимает назначение.
+type UpdateTaskAssigneeRequestV1 struct {
+	Assignee string `json:"assignee"`
+}
+
+type TaskResponseV1 struct {
+	ID          uuid.UUID                `json:"id"`
+	Title       string                   `json:"title"`
+	Description string                   `json:"description"`
+	Status      models.TaskStatus        `json:"status"`
+	Priority    models.TaskPriority      `json:"priority"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+	CompletedAt *time.Time               `json:"completed_at,omitempty"`
+	Duration    int64                    `json:"duration_seconds"`
+	Deadline    *time.Time               `json:"deadline,omitempty"`
+	Intervals   []WorkIntervalResponseV1 `json:"intervals"`
+	Reason      string                   `json:"reason,omitempty"`
+	DeletedAt   *time.Time               `json:"deleted_at,omitempty"`
+	CreatedBy   string                   `json:"created_by,omitempty"`
+	UpdatedBy   string                   `json:"updated_by,omitempty"`
+	Assignee    string                   `json:"assignee,omitempty"`
+	WorkspaceID string                   `json:"workspace_id"`
+}
+
+// WorkIntervalResponseV1 — отрезок активной работы над задачей.
+// -- Для текущего (незакрытого) интервала end отсутствует.
+type WorkIntervalResponseV1 struct {
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
+type TaskListResponseV1 struct {
+	Tasks []TaskResponseV1 `json:"tasks"`
+}
+
+type TaskStatusResponseV1 struct {
+	Status models.TaskStatus `json:"status"`
+}
+
+// BulkCreateTasksRequestV1 — пакетное создание, до 100 задач.
+// -- atomic: создать все или ничего (если хранилище поддерживает транзакции), иначе каждая задача независимо.
+type BulkCreateTasksRequestV1 struct {
+	Tasks  []CreateTaskRequestV1 `json:"tasks" binding:"required,min=1,max=100"`
+	Atomic bool                  `json:"atomic"`
+}
+
+// BulkUpdateStatusRequestV1 — пакетная смена статусов, до 100 задач; atomic — как в BulkCreateTasksRequestV1.
+type BulkUpdateStatusRequestV1 struct {
+	Items  []BulkStatusItemV1 `json:"items" binding:"required,min=1,max=100"`
+	Atomic bool               `json:"atomic"`
+}
+
+// BulkStatusItemV1 — смена статуса одной задачи; ошибки в полях — ошибка этого элемента, а не всего запроса.
+type BulkStatusItemV1 struct {
+	ID     string            `json:"id" binding:"required"`
+	Status models.TaskStatus `json:"status" binding:"required"`
+	Reason string            `json:"reason"`
+}
+
+// BulkResponseV1 — результаты пакетной операции в порядке элементов запроса.
+type BulkResponseV1 struct {
+	Results   []BulkItemResultV1 `json:"results"`
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Atomic    bool               `json:"atomic"`
+}
+
+// BulkItemResultV1 — результат элемента: HTTP-статус, как у одиночного запроса, и задача либо ошибка.
+// -- В атомарном пакете с ошибками остальные элементы получают 424 SERVICE_BATCH_ABORTED.
+type BulkItemResultV1 struct {
+	Index  int             `json:"index"`
+	Status int             `json:"status"`
+	Task   *TaskResponseV1 `json:"task,omitempty"`
+	Error  *ErrorBody      `json:"error,omitempty"`
+}
+
+// TaskRecordV1 — задача в выгрузке (GET /tasks/export) и загрузке (POST /tasks/import).
+// -- Полное состояние, включая статус до удаления: загрузка восстанавливает задачу такой, какой она была.
+// -- duration_seconds не выгружается — он считается по интервалам; пространство — всегда вызывающего.
+type TaskRecordV1 struct {
+	ID             uuid.UUID                `json:"id"`
+	Title          string                   `json:"title"`
+	Description    string                   `json:"description,omitempty"`
+	Status         models.TaskStatus        `json:"status"`
+	PreviousStatus models.TaskStatus        `json:"previous_status,omitempty"`
+	Priority       models.TaskPriority      `json:"priority,omitempty"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+	CompletedAt    *time.Time               `json:"completed_at,omitempty"`
+	Deadline       *time.Time               `json:"deadline,omitempty"`
+	DeletedAt      *time.Time               `json:"deleted_at,omitempty"`
+	Intervals      []WorkIntervalResponseV1 `json:"intervals,omitempty"`
+	Reason         string                   `json:"reason,omitempty"`
+	CreatedBy      string                   `json:"created_by,omitempty"`
+	UpdatedBy      string                   `json:"updated_by,omitempty"`
+	Assignee       string                   `json:"assignee,omitempty"`
+}
+
+// ImportResponseV1 — итог загрузки: сколько задач загружено и ошибки по строкам.
+// -- aborted: файл перестал читаться (битый JSON и т.п.), строки после ошибки не обработаны.
+type ImportResponseV1 struct {
+	Imported int                `json:"imported"`
+	Failed   int                `json:"failed"`
+	Aborted  bool               `json:"aborted"`
+	Errors   []ImportRowErrorV1 `json:"errors"`
+}
+
+// ImportRowErrorV1 — ошибка строки загрузки: HTTP-статус, как у одиночного запроса, и тело ошибки.
+// -- row — номер элемента массива для json и номер строки файла для ndjson и csv (заголовок csv — строка 1).
+type ImportRowErrorV1 struct {
+	Row    int       `json:"row"`
+	ID     string    `json:"id,omitempty"`
+	Status int       `json:"status"`
+	Error  ErrorBody `json:"error"`
+}
+
+type CreateAPIKeyRequestV1 struct {
+	Name      string   `json:"name" binding:"required"`
+	Scopes    []string `json:"scopes" binding:"required"`
+	Workspace string   `json:"workspace,omitempty"`
+}
+
+type CreateAPIKeyResponseV1 struct {
+	Name      string   `json:"name"`
+	Key       string   `json:"key"`
+	Scopes    []string `json:"scopes"`
+	Workspace string   `json:"workspace,omitempty"`
+}
+
+type APIKeyResponseV1 struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	Workspace string   `json:"workspace,omitempty"`
+}
+
+type APIKeyListResponseV1 struct {
+	Keys []APIKeyResponseV1 `json:"keys"`
+}
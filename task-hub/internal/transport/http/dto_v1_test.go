@@ -0,0 +1,60 @@
+package http
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// jsonFields — "имя:тип Go" полей DTO по json-тегам.
+func jsonFields(v any) []string {
+	t := reflect.TypeOf(v)
+	fields := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		fields = append(fields, name+":"+t.Field(i).Type.String())
+	}
+	return fields
+}
+
+// TestDTOV1_Contract — поля v1 нельзя удалять, переименовывать и менять им тип (добавлять можно).
+func TestDTOV1_Contract(t *testing.T) {
+	contract := map[string]struct {
+		dto    any
+		fields []string
+	}{
+		"TaskResponseV1": {TaskResponseV1{}, []string{
+			"id:uuid.UUID", "title:string", "description:string", "status:models.TaskStatus",
+			"priority:models.TaskPriority", "created_at:time.Time", "updated_at:time.Time",
+			"completed_at:*time.Time", "duration_seconds:int64", "deadline:*time.Time",
+			"intervals:[]http.WorkIntervalResponseV1", "reason:string", "deleted_at:*time.Time",
+			"created_by:string", "updated_by:string", "assignee:string", "workspace_id:string",
+		}},
+		"WorkIntervalResponseV1":    {WorkIntervalResponseV1{}, []string{"start:time.Time", "end:*time.Time"}},
+		"TaskListResponseV1":        {TaskListResponseV1{}, []string{"tasks:[]http.TaskResponseV1"}},
+		"TaskStatusResponseV1":      {TaskStatusResponseV1{}, []string{"status:models.TaskStatus"}},
+		"CreateTaskRequestV1":       {CreateTaskRequestV1{}, []string{"title:string", "description:string", "priority:models.TaskPriority", "deadline:*time.Time"}},
+		"UpdateTaskStatusRequestV1": {UpdateTaskStatusRequestV1{}, []string{"status:models.TaskStatus", "reason:string"}},
+		"BulkCreateTasksRequestV1":  {BulkCreateTasksRequestV1{}, []string{"tasks:[]http.CreateTaskRequestV1", "atomic:bool"}},
+		"BulkUpdateStatusRequestV1": {BulkUpdateStatusRequestV1{}, []string{"items:[]http.BulkStatusItemV1", "atomic:bool"}},
+		"BulkStatusItemV1":          {BulkStatusItemV1{}, []string{"id:string", "status:models.TaskStatus", "reason:string"}},
+		"BulkResponseV1":            {BulkResponseV1{}, []string{"results:[]http.BulkItemResultV1", "succeeded:int", "failed:int", "atomic:bool"}},
+		"BulkItemResultV1":          {BulkItemResultV1{}, []string{"index:int", "status:int", "task:*http.TaskResponseV1", "error:*http.ErrorBody"}},
+		"TaskRecordV1": {TaskRecordV1{}, []string{
+			"id:uuid.UUID", "title:string", "description:string", "status:models.TaskStatus",
+			"previous_status:models.TaskStatus", "priority:models.TaskPriority", "created_at:time.Time",
+			"updated_at:time.Time", "completed_at:*time.Time", "deadline:*time.Time", "deleted_at:*time.Time",
+			"intervals:[]http.WorkIntervalResponseV1", "reason:string", "created_by:string", "updated_by:string",
+			"assignee:string",
+		}},
+		"ImportResponseV1":       {ImportResponseV1{}, []string{"imported:int", "failed:int", "aborted:bool", "errors:[]http.ImportRowErrorV1"}},
+		"ImportRowErrorV1":       {ImportRowErrorV1{}, []string{"row:int", "id:string", "status:int", "error:http.ErrorBody"}},
+		"CreateAPIKeyResponseV1": {CreateAPIKeyResponseV1{}, []string{"name:string", "key:string", "scopes:[]string", "workspace:string"}},
+		"APIKeyListResponseV1":   {APIKeyListResponseV1{}, []string{"keys:[]http.APIKeyResponseV1"}},
+	}
+	for name, c := range contract {
+		assert.Subset(t, jsonFields(c.dto), c.fields, name)
+	}
+}
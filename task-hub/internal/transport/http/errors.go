@@ -0,0 +1,134 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+	"github.com/vagonaizer/workmate/task-hub/pkg/logger"
+)
+
+// headerRequestID — заголовок с идентификатором запроса.
+const headerRequestID = "X-Request-ID"
+
+// statusClientClosedRequest — клиент закрыл соединение до ответа (нестандартный, как в nginx).
+const statusClientClosedRequest = 499
+
+// statusByCode — соответствие кодов AppError HTTP-статусам.
+// -- Неизвестные коды отдаются как 500.
+var statusByCode = map[string]int{
+	apperror.ErrRepoNotFound.Code:                   http.StatusNotFound,
+	apperror.ErrRepoSaveFailed.Code:                 http.StatusInternalServerError,
+	apperror.ErrRepoDeleteFailed.Code:               http.StatusInternalServerError,
+	apperror.ErrServiceValidation.Code:              http.StatusUnprocessableEntity,
+	apperror.ErrServiceConflict.Code:                http.StatusConflict,
+	apperror.ErrServiceForbidden.Code:               http.StatusForbidden,
+	apperror.ErrServiceQuotaExceeded.Code:           http.StatusForbidden,
+	apperror.ErrServiceBatchAborted.Code:            http.StatusFailedDependency,
+	apperror.ErrServiceTxUnsupported.Code:           http.StatusNotImplemented,
+	apperror.ErrTransportBadRequest.Code:            http.StatusBadRequest,
+	apperror.ErrTransportUnauthorized.Code:          http.StatusUnauthorized,
+	apperror.ErrTransportForbidden.Code:             http.StatusForbidden,
+	apperror.ErrTransportNotFound.Code:              http.StatusNotFound,
+	apperror.ErrTransportInternal.Code:              http.StatusInternalServerError,
+	apperror.ErrTransportIdempotencyMismatch.Code:   http.StatusUnprocessableEntity,
+	apperror.ErrTransportIdempotencyInProgress.Code: http.StatusConflict,
+	apperror.ErrTransportPayloadTooLarge.Code:       http.StatusRequestEntityTooLarge,
+	apperror.ErrTransportRateLimited.Code:           http.StatusTooManyRequests,
+	apperror.ErrAppInternal.Code:                    http.StatusInternalServerError,
+	apperror.ErrAppTimeout.Code:                     http.StatusGatewayTimeout,
+	apperror.ErrAppCancelled.Code:                   statusClientClosedRequest,
+}
+
+// ErrorMiddleware — единая точка превращения ошибок в HTTP-ответы.
+// -- 1. Хендлеры кладут ошибку в контекст через c.Error(err) и выходят.
+// -- 2. После выполнения цепочки берется последняя ошибка и маппится в ErrorResponse.
+// -- 3. Внутренние ошибки логируются, наружу отдается только общий текст.
+func ErrorMiddleware(log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		err := c.Errors.Last().Err
+		status, body := toErrorResponse(err)
+		body.Error.RequestID = requestID(c)
+		if status >= http.StatusInternalServerError {
+			logger.FromContext(c.Request.Context(), log).Error("%s %s: %v", c.Request.Method, c.FullPath(), err)
+		}
+		c.AbortWithStatusJSON(status, body)
+	}
+}
+
+// RecoveryMiddleware — перехват паники с ответом в едином формате ошибок.
+func RecoveryMiddleware(log *logger.Logger) gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered any) {
+		logger.FromContext(c.Request.Context(), log).Error("panic %s %s: %v", c.Request.Method, c.FullPath(), recovered)
+		_, body := toErrorResponse(apperror.ErrAppInternal)
+		body.Error.RequestID = requestID(c)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, body)
+	})
+}
+
+// toErrorResponse — маппинг ошибки в HTTP-статус и тело ответа.
+// -- 1. AppError — по коду, с подробностями по полям, если они есть.
+// -- 2. Отмена/дедлайн запроса — 499/504.
+// -- 3. Доменные ошибки models — недопустимый переход статуса это конфликт, остальное — валидация.
+// -- 4. Все прочее — внутренняя ошибка без подробностей.
+func toErrorResponse(err error) (int, ErrorResponse) {
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		status, ok := statusByCode[appErr.Code]
+		if !ok {
+			status = http.StatusInternalServerError
+		}
+		body := ErrorBody{Code: appErr.Code, Message: appErr.Message}
+		switch {
+		case status >= http.StatusInternalServerError:
+			// Подробности внутренних ошибок наружу не отдаем.
+		case len(appErr.Details) > 0:
+			body.Details = appErr.Details
+		case appErr.Err != nil:
+			body.Details = appErr.Err.Error()
+		}
+		return status, ErrorResponse{Error: body}
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, ErrorResponse{Error: ErrorBody{
+			Code:    apperror.ErrAppTimeout.Code,
+			Message: apperror.ErrAppTimeout.Message,
+		}}
+	case errors.Is(err, context.Canceled):
+		return statusClientClosedRequest, ErrorResponse{Error: ErrorBody{
+			Code:    apperror.ErrAppCancelled.Code,
+			Message: apperror.ErrAppCancelled.Message,
+		}}
+	case errors.Is(err, models.ErrInvalidStatus):
+		return http.StatusConflict, ErrorResponse{Error: ErrorBody{
+			Code:    apperror.ErrServiceConflict.Code,
+			Message: err.Error(),
+		}}
+	case errors.Is(err, models.ErrInvalidTitle),
+		errors.Is(err, models.ErrInvalidDeadline),
+		errors.Is(err, models.ErrInvalidPriority):
+		return http.StatusUnprocessableEntity, ErrorResponse{Error: ErrorBody{
+			Code:    apperror.ErrServiceValidation.Code,
+			Message: err.Error(),
+		}}
+	}
+
+	return http.StatusInternalServerError, ErrorResponse{Error: ErrorBody{
+		Code:    apperror.ErrAppInternal.Code,
+		Message: apperror.ErrAppInternal.Message,
+	}}
+}
+
+// badRequest — ошибка разбора запроса на транспортном уровне.
+func badRequest(err error) error {
+	return apperror.Wrap(apperror.ErrTransportBadRequest.Code, apperror.ErrTransportBadRequest.Message, err)
+}
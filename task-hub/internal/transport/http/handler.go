@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
 	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
 	"github.com/vagonaizer/workmate/task-hub/internal/domain/ports"
 	"github.com/vagonaizer/workmate/task-hub/pkg/logger"
@@ -22,214 +23,267 @@ func NewHandler(taskService ports.TaskService, logger *logger.Logger) *Handler {
 	return &Handler{taskService: taskService, logger: logger}
 }
 
-// @@route POST /api/tasks
+// @@route POST /api/v1/tasks
 // @@desc  Создать задачу
 // @@accept json
-// @@success 201 TaskResponse
-// @@error 400 Ошибка валидации или данных
+// @@header Idempotency-Key string Ключ идемпотентности: повтор с тем же ключом и телом вернет исходный ответ 201
+// @@success 201 TaskResponseV1
+// @@error 400 Ошибка разбора запроса
+// @@error 409 Запрос с этим Idempotency-Key еще выполняется
+// @@error 413 Тело запроса с Idempotency-Key больше api.idempotency.maxbody
+// @@error 422 Ошибка валидации
+// @@error 422 Idempotency-Key уже использован с другим телом запроса
 func (h *Handler) CreateTask(c *gin.Context) {
-	var req CreateTaskRequest
+	var req CreateTaskRequestV1
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(badRequest(err))
 		return
 	}
 	var deadline time.Time
 	if req.Deadline != nil {
 		deadline = *req.Deadline
 	}
-	task, err := h.taskService.CreateTask(req.Title, req.Description, req.Priority, deadline)
+	task, err := h.taskService.CreateTask(c.Request.Context(), req.Title, req.Description, req.Priority, deadline)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
 	// Сохраняем задачу в файл
-	taskResp := toTaskResponse(task)
+	taskResp := toTaskResponseV1(task)
 	if err := saveTaskToFile(taskResp); err != nil {
-		h.logger.Error("Ошибка сохранения задачи в файл: %v", err)
+		h.log(c).Error("Ошибка сохранения задачи в файл: %v", err)
 	}
 
 	c.JSON(http.StatusCreated, taskResp)
 }
 
-// @@route GET /api/tasks/:id
+// @@route GET /api/v1/tasks/:id
 // @@desc  Получить задачу по id
-// @@success 200 TaskResponse
+// @@success 200 TaskResponseV1
 // @@error 400 invalid id
 // @@error 404 not found
 func (h *Handler) GetTask(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
+	id, err := parseID(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		_ = c.Error(err)
 		return
 	}
-	task, err := h.taskService.GetTask(id)
+	task, err := h.taskService.GetTask(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, toTaskResponse(task))
+	c.JSON(http.StatusOK, toTaskResponseV1(task))
 }
 
-// @@route DELETE /api/tasks/:id
-// @@desc  Удалить задачу по id
+// @@route DELETE /api/v1/tasks/:id
+// @@desc  Переместить задачу в корзину
 // @@success 204
 // @@error 400 invalid id
 // @@error 404 not found
 func (h *Handler) DeleteTask(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
+	id, err := parseID(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		_ = c.Error(err)
 		return
 	}
-	if err := h.taskService.DeleteTask(id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	if err := h.taskService.DeleteTask(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
 
-// @@route GET /api/tasks
-// @@desc  Получить список всех задач
-// @@success 200 TaskListResponse
+// @@route POST /api/v1/tasks/:id/restore
+// @@desc  Восстановить задачу из корзины
+// @@success 204
+// @@error 400 invalid id
+// @@error 404 not found
+// @@error 409 task is not deleted
+func (h *Handler) RestoreTask(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.log(c).Info("Восстановление задачи с id: " + id.String())
+	if err := h.taskService.RestoreTask(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// @@route GET /api/v1/tasks
+// @@desc  Получить список всех задач (?include_deleted=true — вместе с корзиной)
+// @@query include_deleted boolean Включить задачи из корзины
+// @@success 200 TaskListResponseV1
 func (h *Handler) ListTasks(c *gin.Context) {
-	tasks, err := h.taskService.ListTasks()
+	tasks, err := h.taskService.ListTasks(c.Request.Context(), c.Query("include_deleted") == "true")
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
-	resp := make([]TaskResponse, 0, len(tasks))
+	resp := make([]TaskResponseV1, 0, len(tasks))
 	for _, t := range tasks {
-		resp = append(resp, toTaskResponse(t))
+		resp = append(resp, toTaskResponseV1(t))
 	}
-	c.JSON(http.StatusOK, TaskListResponse{Tasks: resp})
+	c.JSON(http.StatusOK, TaskListResponseV1{Tasks: resp})
 }
 
-// @@route PATCH /api/tasks/:id/status
+// @@route PATCH /api/v1/tasks/:id/status
 // @@desc  Изменить статус задачи
 // @@accept json
 // @@success 204
 // @@error 400 invalid id
 // @@error 400 invalid status
 // @@error 404 not found
+// @@error 409 invalid status transition
+// -- Статус "pending" переоткрывает завершенную/отмененную задачу или перезапускает упавшую,
+// -- причина передается в необязательном поле "reason".
 func (h *Handler) UpdateTaskStatus(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
+	id, err := parseID(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		_ = c.Error(err)
 		return
 	}
-	var req struct {
-		Status models.TaskStatus `json:"status" binding:"required"`
-	}
+	var req UpdateTaskStatusRequestV1
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(badRequest(err))
 		return
 	}
-	h.logger.Info("Изменение статуса задачи с id: " + id.String() + " на " + string(req.Status))
-	switch req.Status {
-	case models.TaskStatusInProgress:
-		err = h.taskService.StartTask(id)
-	case models.TaskStatusCompleted:
-		err = h.taskService.CompleteTask(id)
-	case models.TaskStatusCancelled:
-		err = h.taskService.CancelTask(id)
-	case models.TaskStatusFailed:
-		err = h.taskService.FailTask(id)
-	default:
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+	h.log(c).Info("Изменение статуса задачи с id: " + id.String() + " на " + string(req.Status))
+	if !targetStatuses[req.Status] {
+		_ = c.Error(apperror.New(apperror.ErrTransportBadRequest.Code, "invalid status"))
 		return
 	}
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "id": idStr})
+	if err := h.taskService.ChangeStatus(c.Request.Context(), id, req.Status, req.Reason); err != nil {
+		_ = c.Error(err)
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
 
-// @@route GET /api/tasks/:id/status
+// targetStatuses — статусы, в которые задачу можно перевести через API (удаление — отдельным методом).
+var targetStatuses = map[models.TaskStatus]bool{
+	models.TaskStatusPending:    true,
+	models.TaskStatusInProgress: true,
+	models.TaskStatusPaused:     true,
+	models.TaskStatusCompleted:  true,
+	models.TaskStatusCancelled:  true,
+	models.TaskStatusFailed:     true,
+}
+
+// @@route GET /api/v1/tasks/:id/status
 // @@desc  Получить статус задачи
-// @@success 200 {"status": string}
+// @@success 200 TaskStatusResponseV1
 // @@error 400 invalid id
 // @@error 404 not found
 func (h *Handler) GetTaskStatus(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
+	id, err := parseID(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		_ = c.Error(err)
 		return
 	}
-	h.logger.Info("Получение статуса задачи с id: " + id.String())
-	task, err := h.taskService.GetTask(id)
+	h.log(c).Info("Получение статуса задачи с id: " + id.String())
+	task, err := h.taskService.GetTask(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error(), "id": idStr})
+		_ = c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"status": task.Status()})
+	c.JSON(http.StatusOK, TaskStatusResponseV1{Status: task.Status()})
 }
 
-// @@route PATCH /api/tasks/:id/title
+// @@route PATCH /api/v1/tasks/:id/title
 // @@desc  Изменить название задачи
 // @@accept json
 // @@success 204
 // @@error 400 invalid id
-// @@error 400 Ошибка валидации
 // @@error 404 not found
+// @@error 422 Ошибка валидации
 func (h *Handler) UpdateTaskTitle(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
+	id, err := parseID(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		_ = c.Error(err)
 		return
 	}
-	var req struct {
-		Title string `json:"title" binding:"required"`
-	}
+	var req UpdateTaskTitleRequestV1
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(badRequest(err))
 		return
 	}
-	h.logger.Info("Изменение названия задачи с id: %s на %s", id.String(), req.Title)
-	if err := h.taskService.UpdateTitle(id, req.Title); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "id": idStr})
+	h.log(c).Info("Изменение названия задачи с id: %s на %s", id.String(), req.Title)
+	if err := h.taskService.UpdateTitle(c.Request.Context(), id, req.Title); err != nil {
+		_ = c.Error(err)
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
 
-// @@route PATCH /api/tasks/:id/description
+// @@route PATCH /api/v1/tasks/:id/description
 // @@desc  Изменить описание задачи
 // @@accept json
 // @@success 204
 // @@error 400 invalid id
-// @@error 400 Ошибка валидации
 // @@error 404 not found
+// @@error 422 Ошибка валидации
 func (h *Handler) UpdateTaskDescription(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
+	id, err := parseID(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		_ = c.Error(err)
 		return
 	}
-	var req struct {
-		Description string `json:"description"`
+	var req UpdateTaskDescriptionRequestV1
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(badRequest(err))
+		return
 	}
+	h.log(c).Info("Изменение описания задачи с id: " + id.String())
+	if err := h.taskService.UpdateDescription(c.Request.Context(), id, req.Description); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// @@route PATCH /api/v1/tasks/:id/assignee
+// @@desc  Назначить исполнителя задачи (пустая строка снимает назначение)
+// @@accept json
+// @@success 204
+// @@error 400 invalid id
+// @@error 403 forbidden
+// @@error 404 not found
+func (h *Handler) UpdateTaskAssignee(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var req UpdateTaskAssigneeRequestV1
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(badRequest(err))
 		return
 	}
-	h.logger.Info("Изменение описания задачи с id: " + id.String())
-	if err := h.taskService.UpdateDescription(id, req.Description); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "id": idStr})
+	h.log(c).Info("Назначение исполнителя задачи с id: %s на %s", id.String(), req.Assignee)
+	if err := h.taskService.AssignTask(c.Request.Context(), id, req.Assignee); err != nil {
+		_ = c.Error(err)
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
 
-// toTaskResponse — маппинг доменной задачи в DTO
-func toTaskResponse(t *models.Task) TaskResponse {
+// parseID — разбор идентификатора задачи из пути.
+func parseID(c *gin.Context) (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, apperror.Wrap(apperror.ErrTransportBadRequest.Code, "invalid id", err)
+	}
+	return id, nil
+}
+
+// toTaskResponseV1 — маппинг доменной задачи в DTO v1
+func toTaskResponseV1(t *models.Task) TaskResponseV1 {
 	var completedAt *time.Time
 	if !t.CompletedAt().IsZero() {
 		completedAt = &[]time.Time{t.CompletedAt()}[0]
@@ -238,7 +292,19 @@ func toTaskResponse(t *models.Task) TaskResponse {
 	if !t.Deadline().IsZero() {
 		deadline = &[]time.Time{t.Deadline()}[0]
 	}
-	return TaskResponse{
+	var deletedAt *time.Time
+	if !t.DeletedAt().IsZero() {
+		deletedAt = &[]time.Time{t.DeletedAt()}[0]
+	}
+	intervals := make([]WorkIntervalResponseV1, 0, len(t.Intervals()))
+	for _, i := range t.Intervals() {
+		var end *time.Time
+		if !i.End.IsZero() {
+			end = &[]time.Time{i.End}[0]
+		}
+		intervals = append(intervals, WorkIntervalResponseV1{Start: i.Start, End: end})
+	}
+	return TaskResponseV1{
 		ID:          t.ID(),
 		Title:       t.Title(),
 		Description: t.Description(),
@@ -249,13 +315,20 @@ func toTaskResponse(t *models.Task) TaskResponse {
 		CompletedAt: completedAt,
 		Duration:    int64(t.Duration().Seconds()),
 		Deadline:    deadline,
+		Intervals:   intervals,
+		Reason:      t.Reason(),
+		DeletedAt:   deletedAt,
+		CreatedBy:   t.CreatedBy(),
+		UpdatedBy:   t.UpdatedBy(),
+		Assignee:    t.Assignee(),
+		WorkspaceID: t.Workspace(),
 	}
 }
 
 // saveTaskToFile сохраняет задачу в examples/tasks.json
-func saveTaskToFile(task TaskResponse) error {
+func saveTaskToFile(task TaskResponseV1) error {
 	const filePath = "examples/tasks.json"
-	var tasks []TaskResponse
+	var tasks []TaskResponseV1
 
 	// Прочитать существующий файл, если есть
 	if data, err := os.ReadFile(filePath); err == nil && len(data) > 0 {
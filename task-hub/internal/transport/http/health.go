@@ -0,0 +1,52 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vagonaizer/workmate/task-hub/internal/health"
+	"github.com/vagonaizer/workmate/task-hub/internal/version"
+)
+
+// HealthHandler — служебные эндпоинты для оркестратора.
+type HealthHandler struct {
+	checker *health.Checker
+	build   version.Info
+}
+
+func NewHealthHandler(checker *health.Checker, build version.Info) *HealthHandler {
+	return &HealthHandler{checker: checker, build: build}
+}
+
+// @@route GET /healthz
+// @@desc  Проверка живости процесса
+// @@success 200 HealthResponse
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+// @@route GET /readyz
+// @@desc  Проверка готовности принимать трафик (хранилище, фоновые процессы, не идет остановка)
+// @@success 200 ReadinessResponse
+// @@error 503 ReadinessResponse Приложение не готово
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	checks, ready := h.checker.Ready(c.Request.Context())
+	if !ready {
+		c.JSON(http.StatusServiceUnavailable, ReadinessResponse{Status: "unavailable", Checks: checks})
+		return
+	}
+	c.JSON(http.StatusOK, ReadinessResponse{Status: "ok", Checks: checks})
+}
+
+// @@route GET /version
+// @@desc  Информация о сборке
+// @@success 200 VersionResponse
+func (h *HealthHandler) Version(c *gin.Context) {
+	c.JSON(http.StatusOK, VersionResponse{
+		AppName:   h.build.AppName,
+		Version:   h.build.Version,
+		Commit:    h.build.Commit,
+		BuildTime: h.build.BuildTime,
+		GoVersion: h.build.GoVersion,
+	})
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
+	"github.com/vagonaizer/workmate/task-hub/internal/idempotency"
+)
+
+const (
+	// headerIdempotencyKey — ключ идемпотентности от клиента.
+	headerIdempotencyKey = "Idempotency-Key"
+	// headerIdempotentReplayed — ответ повторен из хранилища, запрос не выполнялся.
+	headerIdempotentReplayed = "Idempotent-Replayed"
+	// maxIdempotencyKeyLen — максимальная длина ключа.
+	maxIdempotencyKeyLen = 255
+)
+
+// IdempotencyMiddleware — повтор запросов с заголовком Idempotency-Key.
+// -- 1. Без заголовка запрос выполняется как обычно.
+// -- 2. Ключ действует в пределах рабочего пространства, вызывающего и маршрута (/api и /api/v1 — один маршрут),
+// --    тело запроса сравнивается по sha256.
+// -- 3. Успешный (2xx) ответ сохраняется на TTL хранилища; повтор получает его с заголовком Idempotent-Replayed.
+// --    Ошибку не сохраняем: запрос с тем же ключом можно повторить.
+// -- 4. Тот же ключ с другим телом — 422, пока первый запрос выполняется — 409.
+// -- 5. Тело читается в память целиком (для хеша), поэтому ограничено maxBody байтами; больше — 413.
+// nil-хранилище — заголовок игнорируется.
+func IdempotencyMiddleware(store *idempotency.Store, maxBody int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(headerIdempotencyKey)
+		if store == nil || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			_ = c.Error(apperror.New(apperror.ErrTransportBadRequest.Code, "Idempotency-Key is too long"))
+			c.Abort()
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBody))
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			_ = c.Error(apperror.ErrTransportPayloadTooLarge.WithField("body", fmt.Sprintf("must not exceed %d bytes", tooLarge.Limit)))
+			c.Abort()
+			return
+		case err != nil:
+			_ = c.Error(badRequest(err))
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		sum := sha256.Sum256(body)
+
+		scope := idempotencyScope(c, key)
+		saved, err := store.Begin(scope, hex.EncodeToString(sum[:]))
+		switch {
+		case errors.Is(err, idempotency.ErrMismatch):
+			_ = c.Error(apperror.ErrTransportIdempotencyMismatch)
+			c.Abort()
+			return
+		case errors.Is(err, idempotency.ErrInProgress):
+			_ = c.Error(apperror.ErrTransportIdempotencyInProgress)
+			c.Abort()
+			return
+		case saved != nil:
+			c.Header(headerIdempotentReplayed, "true")
+			c.Data(saved.Status, saved.ContentType, saved.Body)
+			c.Abort()
+			return
+		}
+
+		rec := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = rec
+		completed := false
+		// Release и при панике хендлера, иначе ключ висел бы занятым до истечения TTL.
+		defer func() {
+			if !completed {
+				store.Release(scope)
+			}
+		}()
+		c.Next()
+
+		status := rec.Status()
+		if len(c.Errors) == 0 && rec.Written() && status >= http.StatusOK && status < http.StatusMultipleChoices {
+			store.Complete(scope, idempotency.Response{
+				Status:      status,
+				ContentType: rec.Header().Get("Content-Type"),
+				Body:        rec.body.Bytes(),
+			})
+			completed = true
+		}
+	}
+}
+
+// idempotencyScope — ключ в хранилище: пространство, вызывающий, хендлер и ключ клиента.
+func idempotencyScope(c *gin.Context, key string) string {
+	subject := ""
+	if p, ok := auth.PrincipalFrom(c.Request.Context()); ok {
+		subject = p.Subject
+	}
+	return strings.Join([]string{auth.WorkspaceFrom(c.Request.Context()), subject, c.HandlerName(), key}, "\x00")
+}
+
+// recordingWriter — копия тела ответа для сохранения.
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
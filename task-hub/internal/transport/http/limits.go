@@ -0,0 +1,74 @@
+package http
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
+	"github.com/vagonaizer/workmate/task-hub/internal/ratelimit"
+)
+
+// RateLimitMiddleware — ограничение частоты запросов к API по вызывающему.
+// -- 1. Ключ — subject аутентифицированного вызывающего, для анонимных запросов — адрес клиента.
+// -- 2. Сверх лимита — 429 с заголовком Retry-After (секунды до следующего разрешенного запроса).
+// nil-лимитер — без ограничений.
+func RateLimitMiddleware(limiter *ratelimit.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limiter == nil {
+			c.Next()
+			return
+		}
+		key := "ip:" + c.ClientIP()
+		if p, ok := auth.PrincipalFrom(c.Request.Context()); ok {
+			key = "subject:" + p.Subject
+		}
+		if ok, wait := limiter.Allow(key); !ok {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			_ = c.Error(apperror.ErrTransportRateLimited)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequestTimeout — дедлайн обработки запроса; меняется на лету (горячая перезагрузка конфига), 0 — без дедлайна.
+type RequestTimeout struct {
+	d atomic.Int64
+}
+
+func NewRequestTimeout(d time.Duration) *RequestTimeout {
+	t := &RequestTimeout{}
+	t.Set(d)
+	return t
+}
+
+// Set — новый дедлайн; действует для запросов, начатых после вызова.
+func (t *RequestTimeout) Set(d time.Duration) {
+	t.d.Store(int64(d))
+}
+
+// TimeoutMiddleware — дедлайн на контекст запроса: сервис и хранилище прерываются по ctx, ответ — 504.
+// nil — без дедлайна.
+func TimeoutMiddleware(timeout *RequestTimeout) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout == nil {
+			c.Next()
+			return
+		}
+		d := time.Duration(timeout.d.Load())
+		if d <= 0 {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,162 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vagonaizer/workmate/task-hub/internal/metrics"
+	"github.com/vagonaizer/workmate/task-hub/internal/tracing"
+	"github.com/vagonaizer/workmate/task-hub/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ctxKeyRequestID — ключ идентификатора запроса в gin.Context.
+const ctxKeyRequestID = "request_id"
+
+// maxRequestIDLen — длиннее принятый от клиента X-Request-ID не берем, генерируем свой.
+const maxRequestIDLen = 128
+
+// RequestIDMiddleware — идентификатор запроса.
+// -- 1. Берется из X-Request-ID клиента или генерируется.
+// -- 2. Возвращается в заголовке ответа и кладется в gin.Context.
+// -- 3. В контекст запроса кладется логгер с полем request_id — его подхватывают хендлеры и сервис.
+func RequestIDMiddleware(log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(headerRequestID)
+		if id == "" || len(id) > maxRequestIDLen {
+			id = uuid.NewString()
+		}
+		c.Set(ctxKeyRequestID, id)
+		c.Header(headerRequestID, id)
+		ctx := logger.WithContext(c.Request.Context(), log.With("request_id", id))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// TracingMiddleware — серверный спан на каждый запрос.
+// -- 1. Родительский контекст берется из заголовка traceparent (W3C), если клиент его передал.
+// -- 2. В логгер запроса добавляются trace_id и span_id — по ним логи связываются с трассой.
+// -- 3. Ответы 5xx помечают спан ошибкой.
+func TracingMiddleware(log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		ctx, span := tracing.Tracer().Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", c.Request.URL.Path),
+				attribute.String("client.address", c.ClientIP()),
+				tracing.AttrRequestID.String(requestID(c)),
+			),
+		)
+		defer span.End()
+
+		if traceID, spanID := tracing.IDs(ctx); traceID != "" {
+			ctx = logger.WithContext(ctx, logger.FromContext(ctx, log).With("trace_id", traceID, "span_id", spanID))
+		}
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			if len(c.Errors) > 0 {
+				span.RecordError(c.Errors.Last().Err)
+			}
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
+
+// AccessLogMiddleware — структурированный access log через наш логгер (вместо gin.Logger).
+// -- Уровень зависит от статуса: 5xx — error, 4xx — warn, остальное — info.
+func AccessLogMiddleware(log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		ctx := c.Request.Context()
+		logger.FromContext(ctx, log).Log(ctx, level, "http request",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"route", route,
+			"status", status,
+			"latency_ms", float64(time.Since(start).Microseconds())/1000,
+			"bytes", c.Writer.Size(),
+			"client_ip", c.ClientIP(),
+			"user_agent", c.Request.UserAgent(),
+		)
+	}
+}
+
+// MetricsMiddleware — счетчики и гистограммы HTTP-запросов по маршруту.
+// -- Метка route — шаблон маршрута (/api/tasks/:id), а не путь, чтобы не плодить серии.
+func MetricsMiddleware(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		m.ObserveHTTP(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// DeprecationMiddleware — заголовки устаревшего алиаса API (prefix) для клиентов, которым пора на successor.
+// -- Deprecation (RFC 9745) и Sunset (RFC 8594) — только если заданы даты.
+// -- Link rel="successor-version" — тот же путь в новой версии, например /api/tasks → /api/v1/tasks.
+func DeprecationMiddleware(prefix, successor string, deprecation, sunset time.Time) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h := c.Writer.Header()
+		if !deprecation.IsZero() {
+			h.Set("Deprecation", "@"+strconv.FormatInt(deprecation.Unix(), 10))
+		}
+		if !sunset.IsZero() {
+			h.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+		}
+		path := successor + strings.TrimPrefix(c.Request.URL.Path, prefix)
+		h.Add("Link", "<"+path+`>; rel="successor-version"`)
+		c.Next()
+	}
+}
+
+// requestID — идентификатор текущего запроса.
+func requestID(c *gin.Context) string {
+	if id := c.GetString(ctxKeyRequestID); id != "" {
+		return id
+	}
+	return c.GetHeader(headerRequestID)
+}
+
+// log — логгер запроса (с request_id), если middleware не подключен — общий.
+func (h *Handler) log(c *gin.Context) *logger.Logger {
+	return logger.FromContext(c.Request.Context(), h.logger)
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	_ "embed"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// Спецификация собирается из аннотаций @@ хендлеров и DTO этого пакета (см. internal/openapi).
+// После изменения хендлеров, роутов или dto.go перегенерируйте ее: go generate ./task-hub/internal/transport/http
+//
+//go:generate go run ../../../cmd/openapi-gen -out openapi.json
+
+//go:embed openapi.json
+var openAPISpec []byte
+
+//go:embed swagger-ui.html
+var swaggerUIPage []byte
+
+// OpenAPISpec — GET /openapi.json.
+func OpenAPISpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", openAPISpec)
+}
+
+// SwaggerUI — GET /docs/*filepath: страница Swagger UI со спецификацией /openapi.json.
+// -- Статика Swagger UI встроена в бинарник, внешние CDN не нужны.
+func SwaggerUI() gin.HandlerFunc {
+	static := http.FileServer(http.FS(swaggerFiles.FS))
+	return func(c *gin.Context) {
+		path := strings.TrimPrefix(c.Param("filepath"), "/")
+		if path == "" || path == "index.html" {
+			c.Data(http.StatusOK, "text/html; charset=utf-8", swaggerUIPage)
+			return
+		}
+		if _, err := fs.Stat(swaggerFiles.FS, path); err != nil {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Request.URL.Path = "/" + path
+		static.ServeHTTP(c.Writer, c.Request)
+	}
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/health"
+	"github.com/vagonaizer/workmate/task-hub/internal/metrics"
+	"github.com/vagonaizer/workmate/task-hub/internal/openapi"
+	"github.com/vagonaizer/workmate/task-hub/internal/version"
+	"github.com/vagonaizer/workmate/task-hub/pkg/logger"
+)
+
+// undocumented — маршруты без аннотаций: метрики и сама документация.
+var undocumented = map[string]bool{
+	"GET /metrics":        true,
+	"GET /openapi.json":   true,
+	"GET /docs/*filepath": true,
+}
+
+func TestOpenAPI_UpToDate(t *testing.T) {
+	generated, err := openapi.Generate(openapi.Options{Dir: "."})
+	assert.NoError(t, err)
+	assert.True(t, bytes.Equal(generated, openAPISpec),
+		"openapi.json устарел, выполните go generate ./task-hub/internal/transport/http")
+}
+
+func TestOpenAPI_MatchesRouter(t *testing.T) {
+	log := logger.Nop()
+	router := SetupRouter(
+		NewHandler(nil, log),
+		NewAuthHandler(auth.NewAPIKeyStore(), nil, true, log), // с аутентификацией, иначе нет маршрутов /admin
+		NewHealthHandler(health.New(), version.Info{}),
+		metrics.New(),
+		APIOptions{Legacy: true},
+	)
+	var routes, legacy []string
+	for _, r := range router.Routes() {
+		key := r.Method + " " + r.Path
+		switch {
+		case undocumented[key]:
+		case strings.HasPrefix(r.Path, "/api/") && !strings.HasPrefix(r.Path, "/api/v1/"):
+			// Алиас без версии в спецификацию не входит, но должен повторять /api/v1.
+			legacy = append(legacy, r.Method+" /api/v1"+strings.TrimPrefix(r.Path, "/api"))
+		default:
+			routes = append(routes, key)
+		}
+	}
+	assert.NotEmpty(t, legacy)
+	assert.Subset(t, routes, legacy, "маршруты /api и /api/v1 разошлись")
+
+	var doc openapi.Document
+	assert.NoError(t, json.Unmarshal(openAPISpec, &doc))
+	assert.ElementsMatch(t, routes, doc.Operations(),
+		"маршруты SetupRouter и аннотации @@route разошлись")
+}
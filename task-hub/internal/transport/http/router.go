@@ -1,24 +1,103 @@
 package http
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/vagonaizer/workmate/task-hub/internal/auth"
+	"github.com/vagonaizer/workmate/task-hub/internal/idempotency"
+	"github.com/vagonaizer/workmate/task-hub/internal/metrics"
+	"github.com/vagonaizer/workmate/task-hub/internal/ratelimit"
 )
 
-func SetupRouter(handler *Handler) *gin.Engine {
+// APIOptions — настройки API: версии, идемпотентность и ограничения запросов.
+type APIOptions struct {
+	Legacy      bool      // /api без версии обслуживается как алиас /api/v1
+	Deprecation time.Time // заголовок Deprecation на маршрутах алиаса, нулевое — не отправлять
+	Sunset      time.Time // заголовок Sunset на маршрутах алиаса, нулевое — не отправлять
+
+	Idempotency        *idempotency.Store // ответы по Idempotency-Key для POST /tasks, nil — заголовок игнорируется
+	IdempotencyMaxBody int64              // предел тела запроса с Idempotency-Key в байтах, больше — 413
+	RateLimiter        *ratelimit.Limiter // лимит частоты запросов на вызывающего, nil — без ограничений
+	Timeout            *RequestTimeout    // дедлайн запросов к задачам (кроме выгрузки и загрузки), nil — без дедлайна
+}
+
+func SetupRouter(handler *Handler, authHandler *AuthHandler, healthHandler *HealthHandler, m *metrics.Metrics, api APIOptions) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
-	router := gin.Default()
-	api := router.Group("/api")
+	router := gin.New()
+	router.Use(
+		RequestIDMiddleware(handler.logger),
+		TracingMiddleware(handler.logger),
+		AccessLogMiddleware(handler.logger),
+		MetricsMiddleware(m),
+		RecoveryMiddleware(handler.logger),
+		ErrorMiddleware(handler.logger),
+	)
+	// Метрики Prometheus — вне /api, без аутентификации (закрываются на уровне сети).
+	router.GET("/metrics", gin.WrapH(m.Handler()))
+
+	// Служебные эндпоинты для оркестратора — тоже без аутентификации.
+	router.GET("/healthz", healthHandler.Healthz)
+	router.GET("/readyz", healthHandler.Readyz)
+	router.GET("/version", healthHandler.Version)
+
+	// Документация API: спецификация и Swagger UI.
+	router.GET("/openapi.json", OpenAPISpec)
+	router.GET("/docs/*filepath", SwaggerUI())
+
+	idempotent := IdempotencyMiddleware(api.Idempotency, api.IdempotencyMaxBody)
+	timeout := TimeoutMiddleware(api.Timeout)
+	limit := RateLimitMiddleware(api.RateLimiter)
+	registerV1(router.Group("/api/v1", authHandler.Authenticate(), authHandler.ResolveWorkspace(), limit), handler, authHandler, idempotent, timeout)
+	if api.Legacy {
+		// Старые клиенты ходят в /api без версии: те же хендлеры и DTO v1, плюс заголовки об устаревании.
+		legacy := router.Group("/api",
+			DeprecationMiddleware("/api", "/api/v1", api.Deprecation, api.Sunset),
+			authHandler.Authenticate(),
+			authHandler.ResolveWorkspace(),
+			limit,
+		)
+		registerV1(legacy, handler, authHandler, idempotent, timeout)
+	}
+	return router
+}
+
+// registerV1 — маршруты API v1 в группе api (аутентификация и рабочее пространство уже подключены).
+func registerV1(api *gin.RouterGroup, handler *Handler, authHandler *AuthHandler, idempotent, timeout gin.HandlerFunc) {
+	read := authHandler.Require(auth.ScopeTasksRead)
+	write := authHandler.Require(auth.ScopeTasksWrite)
+
 	// Маршруты для работы с задачами
-	tasks := api.Group("/tasks")
+	tasks := api.Group("/tasks", timeout)
 	{
-		tasks.POST("", handler.CreateTask)
-		tasks.GET("", handler.ListTasks)
-		tasks.GET("/:id", handler.GetTask)
-		tasks.DELETE("/:id", handler.DeleteTask)
-		tasks.PATCH("/:id/status", handler.UpdateTaskStatus)
-		tasks.GET("/:id/status", handler.GetTaskStatus)
-		tasks.PATCH("/:id/title", handler.UpdateTaskTitle)
-		tasks.PATCH("/:id/description", handler.UpdateTaskDescription)
+		tasks.POST("", write, idempotent, handler.CreateTask)
+		tasks.GET("", read, handler.ListTasks)
+		tasks.POST("/bulk", write, handler.CreateTasksBulk)
+		tasks.POST("/bulk/status", write, handler.UpdateTaskStatusBulk)
+		tasks.GET("/:id", read, handler.GetTask)
+		tasks.DELETE("/:id", write, handler.DeleteTask)
+		tasks.POST("/:id/restore", write, handler.RestoreTask)
+		tasks.PATCH("/:id/status", write, handler.UpdateTaskStatus)
+		tasks.GET("/:id/status", read, handler.GetTaskStatus)
+		tasks.PATCH("/:id/title", write, handler.UpdateTaskTitle)
+		tasks.PATCH("/:id/description", write, handler.UpdateTaskDescription)
+		tasks.PATCH("/:id/assignee", write, handler.UpdateTaskAssignee)
+	}
+
+	// Выгрузка и загрузка длятся столько, сколько задач в файле, поэтому без дедлайна task.timeout.
+	transfer := api.Group("/tasks")
+	{
+		transfer.GET("/export", read, handler.ExportTasks)
+		transfer.POST("/import", write, handler.ImportTasks)
+	}
+
+	// Управление API-ключами; без аутентификации Require пропускает всех, поэтому маршрутов нет вовсе
+	if authHandler.enabled {
+		admin := api.Group("/admin", authHandler.Require(auth.ScopeAdmin))
+		{
+			admin.POST("/keys", authHandler.CreateAPIKey)
+			admin.GET("/keys", authHandler.ListAPIKeys)
+			admin.DELETE("/keys/:name", authHandler.RevokeAPIKey)
+		}
 	}
-	return router
 }
@@ -0,0 +1,199 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/ports"
+)
+
+// exportFlushEvery — через сколько задач выгрузка сбрасывает ответ клиенту.
+const exportFlushEvery = 100
+
+// @@route GET /api/v1/tasks/export
+// @@desc  Выгрузить задачи потоком (json — массив, ndjson — задача на строку, csv — с заголовком)
+// @@query format string Формат: json (по умолчанию), ndjson, csv
+// @@query status string Только задачи с этими статусами, через запятую (deleted — задачи из корзины)
+// @@query priority string Только задачи с этими приоритетами, через запятую
+// @@query include_deleted boolean Включить задачи из корзины
+// @@success 200 []TaskRecordV1
+// @@error 400 Неизвестный формат, статус или приоритет
+// -- Ответ пишется по мере обхода задач: ошибка после начала выгрузки только обрывает ответ (для json — без "]").
+func (h *Handler) ExportTasks(c *gin.Context) {
+	format := c.DefaultQuery("format", formatJSON)
+	if _, ok := contentTypes[format]; !ok {
+		_ = c.Error(apperror.New(apperror.ErrTransportBadRequest.Code, "invalid format, expected json, ndjson or csv"))
+		return
+	}
+	filter, err := exportFilter(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	out := newRecordWriter(format, c.Writer)
+	started := false
+	begin := func() error {
+		started = true
+		c.Header("Content-Type", contentTypes[format])
+		c.Header("Content-Disposition", `attachment; filename="tasks.`+format+`"`)
+		c.Status(http.StatusOK)
+		return out.begin()
+	}
+	count := 0
+	err = h.taskService.ExportTasks(c.Request.Context(), filter, func(t *models.Task) error {
+		if !started {
+			if err := begin(); err != nil {
+				return err
+			}
+		}
+		if err := out.write(toTaskRecordV1(t)); err != nil {
+			return err
+		}
+		count++
+		if count%exportFlushEvery == 0 {
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err == nil && !started {
+		err = begin()
+	}
+	if err == nil {
+		err = out.end()
+	}
+	switch {
+	case err != nil && !started:
+		_ = c.Error(err)
+	case err != nil:
+		// Статус уже отправлен — остается только прервать ответ.
+		h.log(c).Error("Выгрузка задач прервана после %d задач: %v", count, err)
+	default:
+		h.log(c).Info("Выгрузка задач (" + format + "): " + strconv.Itoa(count))
+	}
+}
+
+// exportFilter — фильтр выгрузки из query: списки через запятую, параметр можно повторять.
+func exportFilter(c *gin.Context) (ports.TaskFilter, error) {
+	filter := ports.TaskFilter{IncludeDeleted: c.Query("include_deleted") == "true"}
+	for _, v := range queryList(c, "status") {
+		status := models.TaskStatus(v)
+		if !status.Valid() {
+			return filter, apperror.New(apperror.ErrTransportBadRequest.Code, "invalid status "+strconv.Quote(v))
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+	for _, v := range queryList(c, "priority") {
+		priority := models.TaskPriority(v)
+		if !priority.Valid() {
+			return filter, apperror.New(apperror.ErrTransportBadRequest.Code, "invalid priority "+strconv.Quote(v))
+		}
+		filter.Priorities = append(filter.Priorities, priority)
+	}
+	return filter, nil
+}
+
+func queryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(key) {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// @@route POST /api/v1/tasks/import
+// @@desc  Загрузить задачи из выгрузки с сохранением id и временных меток (нужна роль admin)
+// @@accept json []TaskRecordV1
+// @@query format string Формат: json, ndjson, csv; по умолчанию — по Content-Type, иначе json
+// @@success 200 ImportResponseV1
+// @@error 207 ImportResponseV1 Часть строк не загружена, ошибки — в errors
+// @@error 400 Неизвестный формат или файл не начинается как документ формата
+// @@error 403 forbidden
+// -- Строки читаются и загружаются по одной: загруженные до ошибки строки остаются загруженными.
+// -- Ошибки строк — 400 (разбор), 422 (валидация моделью), 409 (задача с таким id уже есть), 403 (квота).
+func (h *Handler) ImportTasks(c *gin.Context) {
+	format, err := importFormat(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	reader, err := newRecordReader(format, c.Request.Body)
+	if err != nil {
+		_ = c.Error(badRequest(err))
+		return
+	}
+
+	ctx := c.Request.Context()
+	resp := ImportResponseV1{Errors: []ImportRowErrorV1{}}
+	for {
+		row, err := reader.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			row.err, resp.Aborted = rowError(err), true
+		} else if row.err == nil {
+			_, row.err = h.taskService.ImportTask(ctx, row.rec.snapshot())
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			_ = c.Error(ctxErr)
+			return
+		}
+		if errors.Is(row.err, apperror.ErrServiceForbidden) {
+			// Права не зависят от строки: отказ — для всего запроса.
+			_ = c.Error(row.err)
+			return
+		}
+		if row.err == nil {
+			resp.Imported++
+			continue
+		}
+		resp.Failed++
+		status, body := toErrorResponse(row.err)
+		body.Error.RequestID = requestID(c)
+		rowErr := ImportRowErrorV1{Row: row.n, Status: status, Error: body.Error}
+		if row.rec.ID != uuid.Nil {
+			rowErr.ID = row.rec.ID.String()
+		}
+		resp.Errors = append(resp.Errors, rowErr)
+		if resp.Aborted {
+			break
+		}
+	}
+	h.log(c).Info("Загрузка задач (" + format + "): загружено " + strconv.Itoa(resp.Imported) + ", с ошибкой " + strconv.Itoa(resp.Failed))
+
+	status := http.StatusOK
+	if resp.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, resp)
+}
+
+// importFormat — формат загрузки: ?format=, иначе по Content-Type, иначе json.
+func importFormat(c *gin.Context) (string, error) {
+	if format := c.Query("format"); format != "" {
+		if _, ok := contentTypes[format]; !ok {
+			return "", apperror.New(apperror.ErrTransportBadRequest.Code, "invalid format, expected json, ndjson or csv")
+		}
+		return format, nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	for format, contentType := range contentTypes {
+		if mediaType == contentType {
+			return format, nil
+		}
+	}
+	return formatJSON, nil
+}
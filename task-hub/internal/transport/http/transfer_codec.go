@@ -0,0 +1,433 @@
+package http
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/workmate/task-hub/internal/common/apperror"
+	"github.com/vagonaizer/workmate/task-hub/internal/domain/models"
+)
+
+// Форматы выгрузки и загрузки задач.
+const (
+	formatJSON   = "json"   // массив задач
+	formatNDJSON = "ndjson" // задача на строку
+	formatCSV    = "csv"    // заголовок и задача на строку, интервалы — "start/end;start/end"
+)
+
+// contentTypes — Content-Type ответа выгрузки и распознавание формата загрузки по заголовку запроса.
+var contentTypes = map[string]string{
+	formatJSON:   "application/json",
+	formatNDJSON: "application/x-ndjson",
+	formatCSV:    "text/csv",
+}
+
+// maxNDJSONLine — максимальная длина строки ndjson при загрузке.
+const maxNDJSONLine = 1 << 20
+
+// csvColumns — колонки csv в порядке выгрузки; при загрузке порядок любой, обязательны csvRequired.
+var csvColumns = []string{
+	"id", "title", "description", "status", "previous_status", "priority",
+	"created_at", "updated_at", "completed_at", "deadline", "deleted_at",
+	"intervals", "reason", "created_by", "updated_by", "assignee",
+}
+
+var csvRequired = []string{"id", "title", "status", "created_at"}
+
+// toTaskRecordV1 — задача в строку выгрузки.
+func toTaskRecordV1(t *models.Task) TaskRecordV1 {
+	s := t.Snapshot()
+	rec := TaskRecordV1{
+		ID:             s.ID,
+		Title:          s.Title,
+		Description:    s.Description,
+		Status:         s.Status,
+		PreviousStatus: s.PrevStatus,
+		Priority:       s.Priority,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+		CompletedAt:    optionalTime(s.CompletedAt),
+		Deadline:       optionalTime(s.Deadline),
+		DeletedAt:      optionalTime(s.DeletedAt),
+		Reason:         s.Reason,
+		CreatedBy:      s.CreatedBy,
+		UpdatedBy:      s.UpdatedBy,
+		Assignee:       s.Assignee,
+	}
+	for _, i := range s.Intervals {
+		rec.Intervals = append(rec.Intervals, WorkIntervalResponseV1{Start: i.Start, End: optionalTime(i.End)})
+	}
+	return rec
+}
+
+// snapshot — строка загрузки в доменное состояние задачи; проверяет его уже модель.
+func (r TaskRecordV1) snapshot() models.TaskSnapshot {
+	s := models.TaskSnapshot{
+		ID:          r.ID,
+		Title:       r.Title,
+		Description: r.Description,
+		Status:      r.Status,
+		PrevStatus:  r.PreviousStatus,
+		Priority:    r.Priority,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+		CompletedAt: derefTime(r.CompletedAt),
+		Deadline:    derefTime(r.Deadline),
+		DeletedAt:   derefTime(r.DeletedAt),
+		Reason:      r.Reason,
+		CreatedBy:   r.CreatedBy,
+		UpdatedBy:   r.UpdatedBy,
+		Assignee:    r.Assignee,
+	}
+	for _, i := range r.Intervals {
+		s.Intervals = append(s.Intervals, models.WorkInterval{Start: i.Start, End: derefTime(i.End)})
+	}
+	return s
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func derefTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+// ==================
+// Выгрузка
+// ==================
+
+// recordWriter — запись задач выгрузки в ответ по одной.
+// -- begin пишет начало документа, write — задачу, end — конец документа и сбрасывает буферы.
+type recordWriter interface {
+	begin() error
+	write(rec TaskRecordV1) error
+	end() error
+}
+
+func newRecordWriter(format string, w io.Writer) recordWriter {
+	switch format {
+	case formatNDJSON:
+		return &ndjsonWriter{enc: json.NewEncoder(w)}
+	case formatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}
+	default:
+		return &jsonArrayWriter{w: w}
+	}
+}
+
+// jsonArrayWriter — массив JSON, элементы пишутся по мере поступления.
+type jsonArrayWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (j *jsonArrayWriter) begin() error {
+	_, err := io.WriteString(j.w, "[")
+	return err
+}
+
+func (j *jsonArrayWriter) write(rec TaskRecordV1) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if j.count > 0 {
+		if _, err := io.WriteString(j.w, ",\n"); err != nil {
+			return err
+		}
+	}
+	j.count++
+	_, err = j.w.Write(data)
+	return err
+}
+
+func (j *jsonArrayWriter) end() error {
+	_, err := io.WriteString(j.w, "]\n")
+	return err
+}
+
+// ndjsonWriter — задача на строку.
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (n *ndjsonWriter) begin() error { return nil }
+
+func (n *ndjsonWriter) write(rec TaskRecordV1) error { return n.enc.Encode(rec) }
+
+func (n *ndjsonWriter) end() error { return nil }
+
+// csvWriter — заголовок csvColumns и задача на строку; csv.Writer копит до 4 КБ, остаток сбрасывает end.
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) begin() error {
+	return c.w.Write(csvColumns)
+}
+
+func (c *csvWriter) write(rec TaskRecordV1) error {
+	return c.w.Write(toCSVRow(rec))
+}
+
+func (c *csvWriter) end() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// toCSVRow — задача в строку csv по csvColumns; время — RFC 3339 с наносекундами, пустое — пустая ячейка.
+func toCSVRow(rec TaskRecordV1) []string {
+	intervals := make([]string, 0, len(rec.Intervals))
+	for _, i := range rec.Intervals {
+		intervals = append(intervals, formatCSVTime(i.Start)+"/"+formatCSVTime(derefTime(i.End)))
+	}
+	return []string{
+		rec.ID.String(), rec.Title, rec.Description, string(rec.Status), string(rec.PreviousStatus), string(rec.Priority),
+		formatCSVTime(rec.CreatedAt), formatCSVTime(rec.UpdatedAt), formatCSVTime(derefTime(rec.CompletedAt)),
+		formatCSVTime(derefTime(rec.Deadline)), formatCSVTime(derefTime(rec.DeletedAt)),
+		strings.Join(intervals, ";"), rec.Reason, rec.CreatedBy, rec.UpdatedBy, rec.Assignee,
+	}
+}
+
+func formatCSVTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// ==================
+// Загрузка
+// ==================
+
+// importRow — строка загрузки: номер (см. ImportRowErrorV1.Row) и задача либо ошибка разбора этой строки.
+type importRow struct {
+	n   int
+	rec TaskRecordV1
+	err error
+}
+
+// recordReader — чтение задач загрузки по одной.
+// -- next возвращает io.EOF в конце файла; другая ошибка next — файл дальше не читается (в row.n — где это случилось).
+// -- Ошибка разбора отдельной строки возвращается в row.err, чтение продолжается.
+type recordReader interface {
+	next() (importRow, error)
+}
+
+// newRecordReader — читатель формата; ошибка — файл не начинается как документ этого формата.
+func newRecordReader(format string, r io.Reader) (recordReader, error) {
+	switch format {
+	case formatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLine)
+		return &ndjsonReader{scanner: scanner}, nil
+	case formatCSV:
+		return newCSVReader(r)
+	default:
+		dec := json.NewDecoder(r)
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("expected a JSON array of tasks: %w", err)
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return nil, errors.New("expected a JSON array of tasks")
+		}
+		return &jsonArrayReader{dec: dec}, nil
+	}
+}
+
+// rowError — ошибка разбора строки: 400, как у невалидного тела одиночного запроса.
+func rowError(err error) error {
+	return apperror.New(apperror.ErrTransportBadRequest.Code, err.Error())
+}
+
+// jsonArrayReader — элементы массива JSON; синтаксическая ошибка обрывает чтение, неверный тип поля — ошибка элемента.
+type jsonArrayReader struct {
+	dec *json.Decoder
+	n   int
+}
+
+func (j *jsonArrayReader) next() (importRow, error) {
+	if !j.dec.More() {
+		if _, err := j.dec.Token(); err != nil {
+			return importRow{n: j.n + 1}, err
+		}
+		return importRow{}, io.EOF
+	}
+	j.n++
+	row := importRow{n: j.n}
+	var raw json.RawMessage
+	if err := j.dec.Decode(&raw); err != nil {
+		return row, err
+	}
+	if err := json.Unmarshal(raw, &row.rec); err != nil {
+		row.err = rowError(err)
+	}
+	return row, nil
+}
+
+// ndjsonReader — задача на строку, пустые строки пропускаются.
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (n *ndjsonReader) next() (importRow, error) {
+	for n.scanner.Scan() {
+		n.line++
+		line := strings.TrimSpace(n.scanner.Text())
+		if line == "" {
+			continue
+		}
+		row := importRow{n: n.line}
+		if err := json.Unmarshal([]byte(line), &row.rec); err != nil {
+			row.err = rowError(err)
+		}
+		return row, nil
+	}
+	if err := n.scanner.Err(); err != nil {
+		return importRow{n: n.line + 1}, err
+	}
+	return importRow{}, io.EOF
+}
+
+// csvReader — строки csv по колонкам заголовка.
+type csvReader struct {
+	r       *csv.Reader
+	columns map[string]int
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("expected a CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("duplicate CSV column %q", name)
+		}
+		columns[name] = i
+	}
+	for name := range columns {
+		if !slices.Contains(csvColumns, name) {
+			return nil, fmt.Errorf("unknown CSV column %q, expected %s", name, strings.Join(csvColumns, ","))
+		}
+	}
+	for _, name := range csvRequired {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing CSV column %q", name)
+		}
+	}
+	return &csvReader{r: reader, columns: columns}, nil
+}
+
+func (c *csvReader) next() (importRow, error) {
+	fields, err := c.r.Read()
+	if errors.Is(err, io.EOF) {
+		return importRow{}, io.EOF
+	}
+	var parseErr *csv.ParseError
+	if err != nil {
+		// Неверное число ячеек — ошибка строки, остальные ошибки разбора (кавычки) ломают файл дальше.
+		if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+			return importRow{n: parseErr.StartLine, err: rowError(err)}, nil
+		}
+		if errors.As(err, &parseErr) {
+			return importRow{n: parseErr.StartLine}, err
+		}
+		return importRow{}, err
+	}
+	line, _ := c.r.FieldPos(0)
+	row := importRow{n: line}
+	row.rec, row.err = c.record(fields)
+	if row.err != nil {
+		row.err = rowError(row.err)
+	}
+	return row, nil
+}
+
+// record — строка csv в задачу; ошибка — первая ячейка, которую не удалось разобрать.
+func (c *csvReader) record(fields []string) (TaskRecordV1, error) {
+	get := func(name string) string {
+		if i, ok := c.columns[name]; ok {
+			return fields[i]
+		}
+		return ""
+	}
+	var rec TaskRecordV1
+	var err error
+	if rec.ID, err = uuid.Parse(get("id")); err != nil {
+		return rec, fmt.Errorf("invalid id: %w", err)
+	}
+	rec.Title, rec.Description, rec.Reason = get("title"), get("description"), get("reason")
+	rec.Status, rec.PreviousStatus = models.TaskStatus(get("status")), models.TaskStatus(get("previous_status"))
+	rec.Priority = models.TaskPriority(get("priority"))
+	rec.CreatedBy, rec.UpdatedBy, rec.Assignee = get("created_by"), get("updated_by"), get("assignee")
+
+	times := []struct {
+		column string
+		dst    *time.Time
+	}{{"created_at", &rec.CreatedAt}, {"updated_at", &rec.UpdatedAt}}
+	for _, t := range times {
+		if *t.dst, err = parseCSVTime(get(t.column)); err != nil {
+			return rec, fmt.Errorf("invalid %s: %w", t.column, err)
+		}
+	}
+	optional := []struct {
+		column string
+		dst    **time.Time
+	}{{"completed_at", &rec.CompletedAt}, {"deadline", &rec.Deadline}, {"deleted_at", &rec.DeletedAt}}
+	for _, t := range optional {
+		v, err := parseCSVTime(get(t.column))
+		if err != nil {
+			return rec, fmt.Errorf("invalid %s: %w", t.column, err)
+		}
+		*t.dst = optionalTime(v)
+	}
+
+	if raw := get("intervals"); raw != "" {
+		for _, part := range strings.Split(raw, ";") {
+			startRaw, endRaw, ok := strings.Cut(part, "/")
+			if !ok {
+				return rec, fmt.Errorf("invalid intervals: %q is not start/end", part)
+			}
+			start, err := parseCSVTime(startRaw)
+			if err != nil {
+				return rec, fmt.Errorf("invalid intervals: %w", err)
+			}
+			end, err := parseCSVTime(endRaw)
+			if err != nil {
+				return rec, fmt.Errorf("invalid intervals: %w", err)
+			}
+			rec.Intervals = append(rec.Intervals, WorkIntervalResponseV1{Start: start, End: optionalTime(end)})
+		}
+	}
+	return rec, nil
+}
+
+// parseCSVTime — RFC 3339, пустая ячейка — нулевое время.
+func parseCSVTime(v string) (time.Time, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, v)
+}
@@ -0,0 +1,38 @@
+package version
+
+import "runtime"
+
+// Значения подставляются при сборке через ldflags, например:
+//
+//	go build -ldflags "-X github.com/vagonaizer/workmate/task-hub/internal/version.Commit=$(git rev-parse --short HEAD)"
+//
+// См. цель build в Makefile.
+var (
+	Version   = "" // версия сборки; если пусто — берется appversion из конфига
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+// Info — информация о сборке для /version.
+type Info struct {
+	AppName   string `json:"app_name"`
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get — информация о сборке; appVersion из конфига используется, если версия не задана при линковке.
+func Get(appName, appVersion string) Info {
+	v := Version
+	if v == "" {
+		v = appVersion
+	}
+	return Info{
+		AppName:   appName,
+		Version:   v,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+}
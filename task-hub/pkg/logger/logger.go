@@ -1,84 +1,218 @@
 package logger
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"os"
 	"runtime"
+	"strings"
 	"time"
 )
 
-// emojiMap — эмодзи для разных уровней логирования.
-var emojiMap = map[string]string{
-	"DEBUG": "🐛",
-	"INFO":  "ℹ️",
-	"WARN":  "⚠️",
-	"ERROR": "❌",
+// ParseLevel — парсинг уровня из строки конфига, неизвестное значение — ошибка.
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("unknown log level %q", s)
+	}
 }
 
-// colorMap — цветовые escape-коды для разных уровней.
-var colorMap = map[string]string{
-	"DEBUG": "\033[36m", // cyan
-	"INFO":  "\033[32m", // green
-	"WARN":  "\033[33m", // yellow
-	"ERROR": "\033[31m", // red
-}
+// Format — формат вывода.
+type Format string
+
+const (
+	FormatText Format = "text" // человекочитаемый вывод с эмодзи
+	FormatJSON Format = "json" // одна JSON-строка на запись, для пайплайнов логов
+)
 
-const colorReset = "\033[0m"
+// Options — настройки логгера.
+type Options struct {
+	Level   string       // debug, info, warn, error
+	Format  Format       // text или json
+	Color   bool         // разрешить ANSI-цвета (включаются только если вывод — терминал)
+	Output  io.Writer    // куда писать, по умолчанию os.Stdout
+	Handler slog.Handler // собственный обработчик; если задан, Level/Format/Color/Output игнорируются
+}
 
+// Logger — обертка над slog.Logger с printf-методами для совместимости.
 type Logger struct {
-	logger *log.Logger
+	sl    *slog.Logger
+	level *slog.LevelVar // общий для логгера и всех его потомков из With; nil для FromHandler
+	sync  func() error   // сброс буферов вывода (для файлов), nil — нечего сбрасывать
 }
 
-// NewLogger — конструктор логгера.
+// NewLogger — конструктор логгера с настройками по умолчанию (text, info).
 func NewLogger() *Logger {
-	return &Logger{
-		logger: log.New(os.Stdout, "", 0),
+	l, _ := New(Options{Color: true})
+	return l
+}
+
+// New — конструктор логгера по настройкам.
+// -- 1. Уровень ниже заданного отбрасывается.
+// -- 2. Цвета выключаются, если вывод не терминал или формат json.
+// -- Неизвестный уровень или формат возвращается ошибкой, но логгер все равно создается (info, text).
+func New(opts Options) (*Logger, error) {
+	if opts.Handler != nil {
+		return FromHandler(opts.Handler), nil
+	}
+	parsed, err := ParseLevel(opts.Level)
+	level := new(slog.LevelVar)
+	level.Set(parsed)
+	out := opts.Output
+	if out == nil {
+		out = os.Stdout
+	}
+	format := opts.Format
+	if format == "" {
+		format = FormatText
+	}
+
+	var h slog.Handler
+	switch format {
+	case FormatJSON:
+		h = slog.NewJSONHandler(out, &slog.HandlerOptions{Level: level, AddSource: true})
+	case FormatText:
+		h = newTextHandler(out, level, opts.Color && isTerminal(out))
+	default:
+		err = fmt.Errorf("unknown log format %q", format)
+		h = newTextHandler(out, level, opts.Color && isTerminal(out))
+	}
+	l := &Logger{sl: slog.New(h), level: level}
+	// stdout/stderr не буферизуются, а fsync на пайпе или терминале возвращает ошибку.
+	if f, ok := out.(interface{ Sync() error }); ok && out != os.Stdout && out != os.Stderr {
+		l.sync = f.Sync
 	}
+	return l, err
 }
 
-// logf — внутренний метод для форматирования и вывода лога.
-func (l *Logger) logf(level string, format string, v ...interface{}) {
-	_, file, line, ok := runtime.Caller(2)
-	if !ok {
-		file = "???"
-		line = 0
+// FromHandler — логгер поверх произвольного slog.Handler.
+func FromHandler(h slog.Handler) *Logger {
+	return &Logger{sl: slog.New(h)}
+}
+
+// Slog — нижележащий slog.Logger, для библиотек, которые принимают его напрямую.
+func (l *Logger) Slog() *slog.Logger {
+	return l.sl
+}
+
+// Handler — нижележащий slog.Handler.
+func (l *Logger) Handler() slog.Handler {
+	return l.sl.Handler()
+}
+
+// With — дочерний логгер с дополнительными полями (пары ключ-значение или slog.Attr).
+func (l *Logger) With(args ...any) *Logger {
+	return &Logger{sl: l.sl.With(args...), level: l.level, sync: l.sync}
+}
+
+// SetLevel — смена уровня на лету (действует и на логгеры, полученные через With).
+// -- Для логгера поверх собственного slog.Handler уровнем управляет сам обработчик — ошибка.
+func (l *Logger) SetLevel(level string) error {
+	if l.level == nil {
+		return fmt.Errorf("log level is managed by the custom handler")
 	}
-	shortFile := file
-	if idx := len(file) - 1; idx >= 0 {
-		for i := len(file) - 1; i >= 0; i-- {
-			if file[i] == '/' {
-				shortFile = file[i+1:]
-				break
-			}
-		}
+	parsed, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	l.level.Set(parsed)
+	return nil
+}
+
+// Enabled — будет ли записан лог этого уровня.
+func (l *Logger) Enabled(ctx context.Context, level slog.Level) bool {
+	return l.sl.Enabled(ctx, level)
+}
+
+// Log — структурированная запись: сообщение и поля в виде пар ключ-значение.
+func (l *Logger) Log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	l.log(ctx, level, msg, args...)
+}
+
+// log — внутренний метод записи, корректно проставляет caller для оберток.
+func (l *Logger) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	if ctx == nil {
+		ctx = context.Background()
 	}
-	emoji := emojiMap[level]
-	color := colorMap[level]
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	msg := fmt.Sprintf(format, v...)
-	l.logger.Printf("%s%s [%s] %s %s:%d | %s%s", color, emoji, level, timestamp, shortFile, line, msg, colorReset)
+	if !l.sl.Enabled(ctx, level) {
+		return
+	}
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // runtime.Callers, log, публичный метод
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	r.Add(args...)
+	_ = l.sl.Handler().Handle(ctx, r)
 }
 
 // Debug — логирование на уровне debug.
 func (l *Logger) Debug(format string, v ...interface{}) {
-	l.logf("DEBUG", format, v...)
+	l.log(context.Background(), slog.LevelDebug, fmt.Sprintf(format, v...))
 }
 
 // Info — логирование на уровне info.
 func (l *Logger) Info(format string, v ...interface{}) {
-	l.logf("INFO", format, v...)
+	l.log(context.Background(), slog.LevelInfo, fmt.Sprintf(format, v...))
 }
 
 // Warn — логирование на уровне warn.
 func (l *Logger) Warn(format string, v ...interface{}) {
-	l.logf("WARN", format, v...)
+	l.log(context.Background(), slog.LevelWarn, fmt.Sprintf(format, v...))
 }
 
 // Error — логирование на уровне error.
 func (l *Logger) Error(format string, v ...interface{}) {
-	l.logf("ERROR", format, v...)
+	l.log(context.Background(), slog.LevelError, fmt.Sprintf(format, v...))
+}
+
+// Sync — сброс записанного на диск при остановке, если логгер пишет в файл.
+func (l *Logger) Sync() error {
+	if l.sync == nil {
+		return nil
+	}
+	return l.sync()
 }
 
-// Sync — для совместимости с zap, ничего не делает.
-func (l *Logger) Sync() error { return nil }
+type ctxKey struct{}
+
+// WithContext — кладет логгер в контекст.
+func WithContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext — логгер из контекста; если его нет, возвращается fallback.
+func FromContext(ctx context.Context, fallback *Logger) *Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(ctxKey{}).(*Logger); ok {
+			return l
+		}
+	}
+	return fallback
+}
+
+// isTerminal — пишет ли w в терминал (цвета в файлах и пайпах только мешают).
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Nop — логгер, который ничего не пишет (для тестов и необязательных зависимостей).
+func Nop() *Logger {
+	return FromHandler(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError + 1}))
+}
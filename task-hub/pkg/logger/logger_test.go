@@ -0,0 +1,100 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_LevelFiltering(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := New(Options{Level: "warn", Output: &buf})
+	assert.NoError(t, err)
+
+	l.Debug("debug")
+	l.Info("info")
+	l.Warn("warn %d", 1)
+	l.Error("error")
+
+	out := buf.String()
+	assert.NotContains(t, out, "debug")
+	assert.NotContains(t, out, "| info")
+	assert.Contains(t, out, "warn 1")
+	assert.Contains(t, out, "error")
+}
+
+func TestLogger_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := New(Options{Level: "debug", Format: FormatJSON, Color: true, Output: &buf})
+	assert.NoError(t, err)
+
+	l.With("task_id", "42").Info("created %s", "task")
+
+	var record map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "created task", record["msg"])
+	assert.Equal(t, "42", record["task_id"])
+	assert.NotContains(t, buf.String(), "\033[")
+}
+
+func TestLogger_NoColorWhenNotTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	l, _ := New(Options{Color: true, Output: &buf})
+	l.Info("plain")
+	assert.False(t, strings.Contains(buf.String(), "\033["))
+}
+
+func TestLogger_UnknownLevel(t *testing.T) {
+	_, err := New(Options{Level: "verbose"})
+	assert.Error(t, err)
+}
+
+func TestLogger_ContextAndCustomHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := slog.NewJSONHandler(&buf, nil)
+	l, err := New(Options{Handler: h})
+	assert.NoError(t, err)
+
+	ctx := WithContext(context.Background(), l.With("request_id", "abc"))
+	FromContext(ctx, NewLogger()).Log(ctx, slog.LevelInfo, "handled", "status", 200)
+
+	var record map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "handled", record["msg"])
+	assert.Equal(t, "abc", record["request_id"])
+	assert.Equal(t, float64(200), record["status"])
+}
+
+func TestLogger_FromContextFallback(t *testing.T) {
+	fallback := NewLogger()
+	assert.Same(t, fallback, FromContext(context.Background(), fallback))
+}
+
+func TestLogger_TextCaller(t *testing.T) {
+	var buf bytes.Buffer
+	l, _ := New(Options{Output: &buf})
+	l.With("task_id", "42").Info("done")
+	assert.Contains(t, buf.String(), "logger_test.go:")
+	assert.Contains(t, buf.String(), "| done task_id=42")
+}
+
+func TestLogger_SetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l, _ := New(Options{Level: "info", Output: &buf})
+	child := l.With("k", "v")
+
+	child.Debug("hidden")
+	assert.NoError(t, l.SetLevel("debug"))
+	child.Debug("visible")
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), "visible")
+
+	assert.Error(t, l.SetLevel("verbose"))
+	assert.Error(t, FromHandler(slog.NewJSONHandler(&buf, nil)).SetLevel("debug"))
+}
@@ -0,0 +1,127 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// emojiMap — эмодзи для разных уровней логирования.
+var emojiMap = map[slog.Level]string{
+	slog.LevelDebug: "🐛",
+	slog.LevelInfo:  "ℹ️",
+	slog.LevelWarn:  "⚠️",
+	slog.LevelError: "❌",
+}
+
+// colorMap — цветовые escape-коды для разных уровней.
+var colorMap = map[slog.Level]string{
+	slog.LevelDebug: "\033[36m", // cyan
+	slog.LevelInfo:  "\033[32m", // green
+	slog.LevelWarn:  "\033[33m", // yellow
+	slog.LevelError: "\033[31m", // red
+}
+
+const colorReset = "\033[0m"
+
+// textHandler — slog.Handler с привычным человекочитаемым выводом:
+// "ℹ️ [INFO] 2006-01-02 15:04:05 file.go:42 | сообщение key=value".
+type textHandler struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	level  slog.Leveler
+	color  bool
+	attrs  []slog.Attr
+	prefix string // префикс ключей из WithGroup
+}
+
+func newTextHandler(out io.Writer, level slog.Leveler, color bool) *textHandler {
+	return &textHandler{mu: &sync.Mutex{}, out: out, level: level, color: color}
+}
+
+func (h *textHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *textHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	level := normalize(r.Level)
+	if h.color {
+		b.WriteString(colorMap[level])
+	}
+	file, line := "???", 0
+	if r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		file, line = filepath.Base(frame.File), frame.Line
+	}
+	fmt.Fprintf(&b, "%s [%s] %s %s:%d | %s", emojiMap[level], r.Level, r.Time.Format("2006-01-02 15:04:05"), file, line, r.Message)
+	for _, a := range h.attrs {
+		writeAttr(&b, "", a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		writeAttr(&b, h.prefix, a)
+		return true
+	})
+	if h.color {
+		b.WriteString(colorReset)
+	}
+	b.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.out, b.String())
+	return err
+}
+
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	child := *h
+	child.attrs = append([]slog.Attr{}, h.attrs...)
+	for _, a := range attrs {
+		a.Key = h.prefix + a.Key
+		child.attrs = append(child.attrs, a)
+	}
+	return &child
+}
+
+func (h *textHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	child := *h
+	child.prefix = h.prefix + name + "."
+	return &child
+}
+
+// writeAttr — печать поля как key=value, группы разворачиваются через точку.
+func writeAttr(b *strings.Builder, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		for _, ga := range a.Value.Group() {
+			writeAttr(b, prefix+a.Key+".", ga)
+		}
+		return
+	}
+	fmt.Fprintf(b, " %s%s=%v", prefix, a.Key, a.Value.Any())
+}
+
+// normalize — приводит промежуточные уровни (например, INFO+2) к ближайшему базовому.
+func normalize(level slog.Level) slog.Level {
+	switch {
+	case level >= slog.LevelError:
+		return slog.LevelError
+	case level >= slog.LevelWarn:
+		return slog.LevelWarn
+	case level >= slog.LevelInfo:
+		return slog.LevelInfo
+	default:
+		return slog.LevelDebug
+	}
+}